@@ -3,25 +3,55 @@ package boost
 import "github.com/snivilised/lorax/internal/ants"
 
 type (
-	IDGenerator = ants.IDGenerator
-	InputParam  = ants.InputParam
-	Option      = ants.Option
-	Options     = ants.Options
-	PoolFunc    = ants.PoolFunc
-	Sequential  = ants.Sequential
-	TaskFunc    = ants.TaskFunc
+	BatchOptions          = ants.BatchOptions
+	CircuitBreaker        = ants.CircuitBreaker
+	CircuitState          = ants.CircuitState
+	IDGenerator           = ants.IDGenerator
+	InputParam            = ants.InputParam
+	LoadBalancingStrategy = ants.LoadBalancingStrategy
+	Option                = ants.Option
+	Options               = ants.Options
+	PoolFunc              = ants.PoolFunc
+	RateLimit             = ants.RateLimit
+	RetryPolicy           = ants.RetryPolicy
+	Sequential            = ants.Sequential
+	TaskFunc              = ants.TaskFunc
+)
+
+const (
+	CircuitClosed   = ants.CircuitClosed
+	CircuitOpen     = ants.CircuitOpen
+	CircuitHalfOpen = ants.CircuitHalfOpen
+
+	RoundRobin = ants.RoundRobin
+	LeastTasks = ants.LeastTasks
+	KeyHash    = ants.KeyHash
+)
+
+var (
+	ErrPoolClosed = ants.ErrPoolClosed
+	ErrTimeout    = ants.ErrTimeout
 )
 
 var (
+	WithBatch            = ants.WithBatch
+	WithCapacity         = ants.WithCapacity
+	WithCircuitBreaker   = ants.WithCircuitBreaker
 	WithDisablePurge     = ants.WithDisablePurge
 	WithExpiryDuration   = ants.WithExpiryDuration
 	WithGenerator        = ants.WithGenerator
 	WithInput            = ants.WithInput
+	WithJobTimeout       = ants.WithJobTimeout
 	WithMaxBlockingTasks = ants.WithMaxBlockingTasks
 	WithNonblocking      = ants.WithNonblocking
 	WithOptions          = ants.WithOptions
+	WithOrderedOutput    = ants.WithOrderedOutput
 	WithOutput           = ants.WithOutput
 	WithPanicHandler     = ants.WithPanicHandler
 	WithPreAlloc         = ants.WithPreAlloc
+	WithPriority         = ants.WithPriority
+	WithRateLimit        = ants.WithRateLimit
+	WithRecoverPanics    = ants.WithRecoverPanics
+	WithRetry            = ants.WithRetry
 	WithSize             = ants.WithSize
 )
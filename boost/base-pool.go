@@ -1,16 +1,40 @@
 package boost
 
 import (
+	"context"
+	"sort"
+	"sync"
 	"sync/atomic"
+	"time"
 )
 
 type (
 	basePool[I, O any] struct {
-		wg         WaitGroup
-		sequence   int32
-		inputDupCh *Duplex[I]
-		oi         *outputInfo[O]
-		ending     bool
+		wg           WaitGroup
+		sequence     int32
+		inputDupCh   *Duplex[I]
+		oi           *outputInfo[O]
+		ordered      *resequencer[O]
+		inFlight     int64
+		concluded    int32
+		finish       func()
+		concludeOnce sync.Once
+		finishOnce   sync.Once
+		stats        collector
+		limiter      *limiter
+		capacity     *capacity
+		middleware   []Middleware[I, O]
+		closed       int32
+		mutex        sync.Mutex
+		pending      map[int]Job[I]
+		paused       int32
+		workers      pausable
+	}
+
+	// pausable is implemented by the underlying ants pools.
+	pausable interface {
+		Pause()
+		Resume()
 	}
 )
 
@@ -31,3 +55,356 @@ func (p *basePool[I, O]) CancelCh() CancelStreamR {
 
 	return nil
 }
+
+// enter marks the start of a job's lifetime, which begins when it is
+// posted to the pool.
+func (p *basePool[I, O]) enter() {
+	atomic.AddInt64(&p.inFlight, 1)
+}
+
+// leave marks the end of a job's lifetime, which is either when its
+// response has been delivered or it failed to be dispatched to a worker.
+// When the last outstanding job of a concluded pool leaves, the pool
+// is finished, unless it is paused.
+func (p *basePool[I, O]) leave() {
+	if atomic.AddInt64(&p.inFlight, -1) == 0 {
+		p.settle()
+	}
+}
+
+// settle finishes the pool if it has been concluded and is not paused.
+// The caller must have established that no job remains in flight.
+func (p *basePool[I, O]) settle() {
+	if atomic.LoadInt32(&p.concluded) == 1 && atomic.LoadInt32(&p.paused) == 0 {
+		p.end()
+	}
+}
+
+// Pause stops workers from being handed new jobs. Jobs already running
+// are allowed to complete, but jobs posted whilst the pool is paused are
+// either blocked or rejected according to the blocking options, as if
+// the pool were at capacity. A paused pool is never regarded as finished,
+// even if it has been concluded.
+func (p *basePool[I, O]) Pause() {
+	atomic.StoreInt32(&p.paused, 1)
+
+	if p.workers != nil {
+		p.workers.Pause()
+	}
+}
+
+// Resume allows a paused pool to continue handing jobs to workers.
+func (p *basePool[I, O]) Resume() {
+	if !atomic.CompareAndSwapInt32(&p.paused, 1, 0) {
+		return
+	}
+
+	if p.workers != nil {
+		p.workers.Resume()
+	}
+
+	if atomic.LoadInt64(&p.inFlight) == 0 {
+		p.settle()
+	}
+}
+
+// IsPaused indicates whether the pool is paused.
+func (p *basePool[I, O]) IsPaused() bool {
+	return atomic.LoadInt32(&p.paused) == 1
+}
+
+// outputs creates the output stream, if requested by the options, and
+// returns the output info through which workers send their outputs. When
+// ordered output has been requested, workers send to the resequencer
+// instead, which in turn forwards outputs to the client in order.
+func (p *basePool[I, O]) outputs(ctx context.Context, o *Options) *outputInfoW[O] {
+	if p.oi = newOutputInfo[O](o); p.oi == nil {
+		return nil
+	}
+
+	wi := fromOutputInfo(o, p.oi)
+	wi.stats = &p.stats
+
+	if o.ReorderBound == 0 {
+		return wi
+	}
+
+	p.ordered = newResequencer(o.ReorderBound, wi)
+	p.ordered.run(ctx)
+
+	return p.ordered.writer()
+}
+
+// throttle blocks until the job with the key is permitted by the rate
+// limit, if one has been defined.
+func (p *basePool[I, O]) throttle(ctx context.Context, key string) error {
+	if p.limiter != nil {
+		return p.limiter.wait(ctx, key)
+	}
+
+	return nil
+}
+
+// RateLimitKeys returns the number of keys currently tracked by the
+// per-key rate limit. The buckets of keys that have been idle for long
+// enough to be refilled are evicted, so this does not grow without bound.
+func (p *basePool[I, O]) RateLimitKeys() int {
+	if p.limiter != nil {
+		return p.limiter.keys()
+	}
+
+	return 0
+}
+
+// weigh blocks until the capacity, if defined, is able to accommodate
+// the weight of the job.
+func (p *basePool[I, O]) weigh(ctx context.Context, weight int64) error {
+	if p.capacity != nil {
+		return p.capacity.acquire(ctx, weight)
+	}
+
+	return nil
+}
+
+// unweigh releases the weight of a job whose lifetime has ended.
+func (p *basePool[I, O]) unweigh(weight int64) {
+	if p.capacity != nil {
+		p.capacity.release(weight)
+	}
+}
+
+// InUseWeight returns the total weight of the jobs currently in flight,
+// which is always 0 when no capacity has been defined.
+func (p *basePool[I, O]) InUseWeight() int64 {
+	if p.capacity != nil {
+		return p.capacity.inUse.Load()
+	}
+
+	return 0
+}
+
+// admit blocks until the pool is able to accept another job. This is
+// only required for ordered output, where the number of jobs whose
+// outputs have yet to be released is bounded.
+func (p *basePool[I, O]) admit(ctx context.Context) error {
+	if p.ordered != nil {
+		return p.ordered.acquire(ctx)
+	}
+
+	return nil
+}
+
+// dispatch tracks the job whilst it is submitted to the underlying pool,
+// via the submit function.
+func (p *basePool[I, O]) dispatch(job Job[I], submit func() error) error {
+	p.hold(job)
+	p.enter()
+
+	err := p.submit(submit)
+	if err != nil {
+		p.fail(job.SequenceNo)
+	}
+
+	return err
+}
+
+// invoke executes the job received by the ants pool function.
+func (p *basePool[I, O]) invoke(ctx context.Context,
+	exec *executor[I, O],
+	fn ManifoldFuncCtx[I, O],
+	input InputParam,
+) {
+	if job, ok := input.(Job[I]); ok {
+		p.started(job.SequenceNo)
+		p.guard(job.SequenceNo, func() {
+			exec.run(ctx, fn, job)
+		})
+	}
+}
+
+// guard runs the job, which is expected to deliver its output. A job that
+// panics without being recovered never does, so with ordered output, its
+// sequence number is skipped; otherwise the outputs that follow would be
+// held behind it indefinitely, until the reorder bound blocks Post.
+func (p *basePool[I, O]) guard(sequenceNo int, run func()) {
+	if p.ordered != nil {
+		settled := false
+
+		defer func() {
+			if !settled {
+				p.ordered.skip(sequenceNo)
+			}
+		}()
+
+		run()
+		settled = true
+
+		return
+	}
+
+	run()
+}
+
+// accepting returns ErrPoolClosed once the pool has been shut down.
+func (p *basePool[I, O]) accepting() error {
+	if atomic.LoadInt32(&p.closed) == 1 {
+		return ErrPoolClosed
+	}
+
+	return nil
+}
+
+// hold registers the job as pending, until it is started.
+func (p *basePool[I, O]) hold(job Job[I]) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.pending == nil {
+		p.pending = make(map[int]Job[I])
+	}
+
+	p.pending[job.SequenceNo] = job
+}
+
+// started indicates the job is no longer pending.
+func (p *basePool[I, O]) started(sequenceNo int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	delete(p.pending, sequenceNo)
+}
+
+// fail ends the lifetime of a job that could not be dispatched. The job
+// remains pending if the pool is being shut down, so that it can be
+// returned by Shutdown.
+func (p *basePool[I, O]) fail(sequenceNo int) {
+	if atomic.LoadInt32(&p.closed) == 0 {
+		p.started(sequenceNo)
+	}
+
+	p.abandon(sequenceNo)
+}
+
+// shutdown stops the pool from accepting new jobs, then releases the
+// underlying pool, waiting up to the grace period for in-flight jobs to
+// complete. The jobs that were never started are returned in order of
+// SequenceNo.
+func (p *basePool[I, O]) shutdown(ctx context.Context,
+	grace time.Duration,
+	release func(ctx context.Context, timeout time.Duration) error,
+	conclude func(ctx context.Context),
+) ([]Job[I], error) {
+	if !atomic.CompareAndSwapInt32(&p.closed, 0, 1) {
+		return nil, ErrPoolClosed
+	}
+
+	err := release(ctx, grace)
+
+	// a pool that is shut down whilst paused must still be able to finish.
+	atomic.StoreInt32(&p.paused, 0)
+
+	if conclude != nil {
+		conclude(ctx)
+	}
+
+	p.mutex.Lock()
+	unstarted := make([]Job[I], 0, len(p.pending))
+
+	for _, job := range p.pending {
+		unstarted = append(unstarted, job)
+	}
+	p.mutex.Unlock()
+
+	sort.Slice(unstarted, func(i, j int) bool {
+		return unstarted[i].SequenceNo < unstarted[j].SequenceNo
+	})
+
+	return unstarted, err
+}
+
+// submit counts the job as submitted, unless the submit function fails.
+func (p *basePool[I, O]) submit(submit func() error) error {
+	p.stats.submit()
+
+	err := submit()
+	if err != nil {
+		p.stats.withdraw()
+	}
+
+	return err
+}
+
+// track wraps a task of a raw task based pool, so that its execution
+// is measured.
+func (p *basePool[I, O]) track(task TaskFunc) TaskFunc {
+	posted := time.Now()
+
+	return func() {
+		_ = p.stats.measure(posted, func() error {
+			task()
+
+			return nil
+		})
+	}
+}
+
+// abandon ends the lifetime of a job that will never be executed.
+func (p *basePool[I, O]) abandon(sequenceNo int) {
+	if p.ordered != nil {
+		p.ordered.skip(sequenceNo)
+	}
+
+	p.leave()
+}
+
+// conclude registers finish to be invoked once all outstanding jobs have
+// completed. If the context has been cancelled by that time, then the
+// context's error is passed to finish. conclude is idempotent, so
+// subsequent calls after the first have no effect, even when they are
+// made concurrently, eg by a source terminator and the client.
+func (p *basePool[I, O]) conclude(ctx context.Context, finish func(err error)) {
+	p.concludeOnce.Do(func() {
+		p.finish = func() {
+			finish(ctx.Err())
+		}
+		atomic.StoreInt32(&p.concluded, 1)
+
+		if atomic.LoadInt64(&p.inFlight) == 0 {
+			p.settle()
+		}
+	})
+}
+
+func (p *basePool[I, O]) end() {
+	p.finishOnce.Do(p.finish)
+}
+
+// closeOutput closes the output channel. This is safe because it is
+// only invoked once no job remains that could send to it. With ordered
+// output, the output channel is closed by the resequencer, once it has
+// released all outputs.
+func (p *basePool[I, O]) closeOutput(error) {
+	if p.ordered != nil {
+		p.ordered.close()
+
+		return
+	}
+
+	close(p.oi.outputDupCh.Channel)
+}
+
+// withInput derives a job with the same identity as the one provided, but
+// with a different input; used by the task based pools, whose pending
+// jobs carry the task rather than just the input.
+func withInput[I, T any](job Job[I], input T) Job[T] {
+	return Job[T]{
+		ID:         job.ID,
+		SequenceNo: job.SequenceNo,
+		Input:      input,
+		Deadline:   job.Deadline,
+		Priority:   job.Priority,
+		Weight:     job.Weight,
+		posted:     job.posted,
+		entry:      job.entry,
+	}
+}
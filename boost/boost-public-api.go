@@ -1,5 +1,9 @@
 package boost
 
+import (
+	"time"
+)
+
 const (
 	MaxWorkers = 100
 )
@@ -13,6 +17,27 @@ type (
 		ID         string
 		SequenceNo int
 		Input      I
+
+		// Deadline is optional and denotes the time by which the job must
+		// have completed. A job that overruns its deadline results in an
+		// output whose Error is a JobTimeoutError.
+		Deadline time.Time
+
+		// Priority is only observed when priority scheduling has been
+		// enabled with the WithPriority option. Jobs with higher values
+		// are dispatched first.
+		Priority int
+
+		// Weight is only observed when a capacity has been defined with the
+		// WithCapacity option and denotes how many units of capacity the
+		// job occupies whilst in flight. A weight of 0 is treated as 1.
+		Weight int64
+
+		// posted is the time at which the job was posted to the pool.
+		posted time.Time
+
+		// entry identifies the job in the journal, if there is one.
+		entry uint64
 	}
 
 	JobOutput[O any] struct {
@@ -20,6 +45,10 @@ type (
 		SequenceNo int
 		Payload    O
 		Error      error
+
+		// Attempts denotes how many times the job was executed, which is
+		// only greater than 1 when a retry policy is in effect.
+		Attempts int
 	}
 
 	JobStream[I any]  chan Job[I]
@@ -46,8 +75,12 @@ type (
 	CancelStreamR    = <-chan CancelWorkSignal
 	CancelStreamW    = chan<- CancelWorkSignal
 
+	// PoolResult represents the overall result of the pool. For those
+	// pools that aggregate job errors, Error contains all job errors
+	// joined together and FirstFailure identifies the first job to fail.
 	PoolResult struct {
-		Error error
+		Error        error
+		FirstFailure *JobError
 	}
 
 	PoolResultStream  = chan *PoolResult
@@ -57,6 +90,9 @@ type (
 	// OnCancel is the callback required by StartCancellationMonitor
 	OnCancel func()
 
+	// OnCancelReason is the callback required by StartSignalMonitor
+	OnCancelReason func(reason CancelReason)
+
 	// WaitGroup allows the core sync.WaitGroup to be decorated by the client
 	// for debugging purposes.
 	WaitGroup interface {
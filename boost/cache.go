@@ -0,0 +1,286 @@
+package boost
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type (
+	// Cache stores the payloads of jobs that completed successfully, keyed
+	// by the key derived from their input, so that a subsequent job with
+	// the same key does not have to be executed. Implementations must be
+	// safe for concurrent use.
+	Cache[O any] interface {
+		Get(key string) (O, bool)
+		Put(key string, payload O)
+	}
+
+	// memo is the result cache of a pool, as defined by the WithCache
+	// option.
+	memo[I, O any] struct {
+		cache   Cache[O]
+		keyFunc KeyFunc[I]
+	}
+
+	// LRUCache is an in-memory Cache that holds a bounded number of
+	// payloads, evicting the least recently used when full.
+	LRUCache[O any] struct {
+		mutex   sync.Mutex
+		size    int
+		order   *list.List
+		entries map[string]*list.Element
+	}
+
+	// lruEntry is an element of the recency list of an LRUCache.
+	lruEntry[O any] struct {
+		key     string
+		payload O
+	}
+
+	// TTLCache is an in-memory Cache whose payloads expire once they have
+	// been held for the time to live. Expired payloads are evicted lazily.
+	TTLCache[O any] struct {
+		mutex   sync.Mutex
+		ttl     time.Duration
+		entries map[string]ttlEntry[O]
+		sweep   int
+	}
+
+	// ttlEntry is a payload held by a TTLCache.
+	ttlEntry[O any] struct {
+		payload O
+		expiry  time.Time
+	}
+
+	// FileCache is a Cache backed by a directory, in which each payload
+	// is stored in its own file, encoded by the codec, so that payloads
+	// survive across runs. Since the cache is only an optimisation, a
+	// payload that can not be read is treated as a miss and a payload that
+	// can not be written is simply not cached; the most recent such
+	// failure is reported by Err.
+	FileCache[O any] struct {
+		dir   string
+		codec Codec[O]
+		mutex sync.Mutex
+		err   error
+	}
+)
+
+const (
+	// minSweep is the number of entries a TTLCache holds before it first
+	// sweeps away expired entries.
+	minSweep = 64
+
+	// fileCachePerm is the permission with which the directory of a
+	// FileCache is created.
+	fileCachePerm os.FileMode = 0o755
+)
+
+func (m *memo[I, O]) get(input I) (O, bool) {
+	return m.cache.Get(m.keyFunc(input))
+}
+
+func (m *memo[I, O]) put(input I, payload O) {
+	m.cache.Put(m.keyFunc(input), payload)
+}
+
+// NewLRUCache creates an LRUCache that holds up to size payloads.
+func NewLRUCache[O any](size int) *LRUCache[O] {
+	return &LRUCache[O]{
+		size:    max(size, 1),
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+func (c *LRUCache[O]) Get(key string) (O, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, found := c.entries[key]; found {
+		c.order.MoveToFront(element)
+		entry, _ := element.Value.(*lruEntry[O])
+
+		return entry.payload, true
+	}
+
+	var zero O
+
+	return zero, false
+}
+
+func (c *LRUCache[O]) Put(key string, payload O) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, found := c.entries[key]; found {
+		entry, _ := element.Value.(*lruEntry[O])
+		entry.payload = payload
+		c.order.MoveToFront(element)
+
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry[O]{
+		key:     key,
+		payload: payload,
+	})
+
+	if c.order.Len() > c.size {
+		entry, _ := c.order.Remove(c.order.Back()).(*lruEntry[O])
+		delete(c.entries, entry.key)
+	}
+}
+
+// Len returns the number of payloads held.
+func (c *LRUCache[O]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.order.Len()
+}
+
+// NewTTLCache creates a TTLCache whose payloads expire after ttl.
+func NewTTLCache[O any](ttl time.Duration) *TTLCache[O] {
+	return &TTLCache[O]{
+		ttl:     ttl,
+		entries: make(map[string]ttlEntry[O]),
+		sweep:   minSweep,
+	}
+}
+
+func (c *TTLCache[O]) Get(key string) (O, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if entry, found := c.entries[key]; found {
+		if time.Now().Before(entry.expiry) {
+			return entry.payload, true
+		}
+
+		delete(c.entries, key)
+	}
+
+	var zero O
+
+	return zero, false
+}
+
+// Put stores the payload. Whenever the number of entries has doubled
+// since the last sweep, the expired entries are swept away, so that keys
+// that are never requested again do not accumulate indefinitely.
+func (c *TTLCache[O]) Put(key string, payload O) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	c.entries[key] = ttlEntry[O]{
+		payload: payload,
+		expiry:  now.Add(c.ttl),
+	}
+
+	if len(c.entries) < c.sweep {
+		return
+	}
+
+	for k, entry := range c.entries {
+		if !now.Before(entry.expiry) {
+			delete(c.entries, k)
+		}
+	}
+
+	c.sweep = max(len(c.entries)*2, minSweep)
+}
+
+// NewFileCache creates a FileCache in dir, which is created if it does
+// not exist.
+func NewFileCache[O any](dir string, codec Codec[O]) (*FileCache[O], error) {
+	if err := os.MkdirAll(dir, fileCachePerm); err != nil {
+		return nil, err
+	}
+
+	return &FileCache[O]{
+		dir:   dir,
+		codec: codec,
+	}, nil
+}
+
+// path returns the path of the file in which the payload of the key is
+// stored. Keys are hashed, since they may not be valid file names.
+func (c *FileCache[O]) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+func (c *FileCache[O]) Get(key string) (O, bool) {
+	var zero O
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return zero, false
+	}
+
+	payload, err := c.codec.Decode(data)
+	if err != nil {
+		c.fail(err)
+
+		return zero, false
+	}
+
+	return payload, true
+}
+
+// Put writes the payload to a temporary file, which is then renamed, so
+// that a concurrent Get never observes a partially written payload.
+func (c *FileCache[O]) Put(key string, payload O) {
+	data, err := c.codec.Encode(payload)
+	if err != nil {
+		c.fail(err)
+
+		return
+	}
+
+	path := c.path(key)
+
+	file, err := os.CreateTemp(c.dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		c.fail(err)
+
+		return
+	}
+
+	_, err = file.Write(data)
+	if e := file.Close(); err == nil {
+		err = e
+	}
+
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+
+	if err != nil {
+		_ = os.Remove(file.Name())
+		c.fail(err)
+	}
+}
+
+// Err returns the most recent failure to read or write a payload.
+func (c *FileCache[O]) Err() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.err
+}
+
+func (c *FileCache[O]) fail(err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.err = err
+}
@@ -0,0 +1,186 @@
+package boost_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // ginkgo ok
+	. "github.com/onsi/gomega"    //nolint:revive // gomega ok
+
+	"github.com/snivilised/lorax/boost"
+)
+
+var _ = Describe("Cache", func() {
+	key := func(input int) string {
+		return strconv.Itoa(input)
+	}
+
+	Context("ManifoldFuncPool", func() {
+		DescribeTable("🧪 should: emit cached output without executing job",
+			func(specCtx SpecContext, create func() boost.Cache[int]) {
+				var (
+					wg    sync.WaitGroup
+					calls int32
+				)
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewManifoldFuncPool(
+					ctx, func(input int) (int, error) {
+						atomic.AddInt32(&calls, 1)
+
+						return input * 2, nil
+					}, &wg,
+					boost.WithSize(1),
+					boost.WithOutput(10, 0, TimeoutOnSend),
+					boost.WithCache(create(), key),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				Expect(pool.Post(ctx, 21)).To(Succeed())
+
+				var output boost.JobOutput[int]
+				Eventually(pool.Observe()).Should(Receive(&output))
+				Expect(output.Payload).To(Equal(42))
+
+				Expect(pool.Post(ctx, 21)).To(Succeed())
+				Eventually(pool.Observe()).Should(Receive(&output))
+				Expect(output.Payload).To(Equal(42))
+				Expect(output.SequenceNo).To(Equal(2))
+				Expect(output.ID).NotTo(BeEmpty())
+
+				pool.Conclude(ctx)
+				Eventually(pool.Observe()).Should(BeClosed())
+				Expect(atomic.LoadInt32(&calls)).To(BeEquivalentTo(1))
+
+				stats := pool.Stats()
+				Expect(stats.CacheHits).To(BeEquivalentTo(1))
+				Expect(stats.CacheMisses).To(BeEquivalentTo(1))
+				Expect(stats.Submitted).To(BeEquivalentTo(2))
+				Expect(stats.Completed).To(BeEquivalentTo(2))
+			},
+			Entry("lru", func() boost.Cache[int] {
+				return boost.NewLRUCache[int](10)
+			}),
+			Entry("ttl", func() boost.Cache[int] {
+				return boost.NewTTLCache[int](time.Minute)
+			}),
+			Entry("file", func() boost.Cache[int] {
+				cache, err := boost.NewFileCache(GinkgoT().TempDir(), boost.JSONCodec[int]{})
+				Expect(err).To(Succeed())
+
+				return cache
+			}),
+		)
+	})
+
+	DescribeTable("🧪 should: be rejected by pools that do not look it up",
+		func(specCtx SpecContext, create func(ctx context.Context, wg *sync.WaitGroup) error) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			Expect(create(ctx, &wg)).To(MatchError(boost.ErrOptionUnsupported))
+		},
+		Entry("ManifoldTaskPool", func(ctx context.Context, wg *sync.WaitGroup) error {
+			_, err := boost.NewManifoldTaskPool[int, int](ctx, wg,
+				boost.WithCache(boost.NewLRUCache[int](10), key),
+			)
+
+			return err
+		}),
+		Entry("MultiManifoldFuncPool", func(ctx context.Context, wg *sync.WaitGroup) error {
+			_, err := boost.NewMultiManifoldFuncPool(
+				ctx, demoPoolManifoldFunc, wg, 2, boost.RoundRobin,
+				boost.WithCache(boost.NewLRUCache[int](10), key),
+			)
+
+			return err
+		}),
+		Entry("FuncPoolE", func(ctx context.Context, wg *sync.WaitGroup) error {
+			_, err := boost.NewFuncPoolE(ctx, failOdd, wg,
+				boost.WithCache(boost.NewLRUCache[any](10), key),
+			)
+
+			return err
+		}),
+		Entry("TaskPoolE", func(ctx context.Context, wg *sync.WaitGroup) error {
+			_, err := boost.NewTaskPoolE[int](ctx, wg,
+				boost.WithCache(boost.NewLRUCache[any](10), key),
+			)
+
+			return err
+		}),
+	)
+
+	Context("LRUCache", func() {
+		When("full", func() {
+			It("🧪 should: evict least recently used", func() {
+				cache := boost.NewLRUCache[int](2)
+				cache.Put("a", 1)
+				cache.Put("b", 2)
+
+				_, found := cache.Get("a")
+				Expect(found).To(BeTrue())
+
+				cache.Put("c", 3)
+				Expect(cache.Len()).To(Equal(2))
+
+				_, found = cache.Get("b")
+				Expect(found).To(BeFalse())
+
+				payload, found := cache.Get("a")
+				Expect(found).To(BeTrue())
+				Expect(payload).To(Equal(1))
+			})
+		})
+	})
+
+	Context("TTLCache", func() {
+		When("time to live has elapsed", func() {
+			It("🧪 should: miss", func() {
+				cache := boost.NewTTLCache[int](time.Millisecond * 10)
+				cache.Put("a", 1)
+
+				payload, found := cache.Get("a")
+				Expect(found).To(BeTrue())
+				Expect(payload).To(Equal(1))
+
+				Eventually(func() bool {
+					_, found := cache.Get("a")
+
+					return found
+				}).Should(BeFalse())
+			})
+		})
+	})
+
+	Context("FileCache", func() {
+		When("reopened", func() {
+			It("🧪 should: retain payloads", func() {
+				dir := GinkgoT().TempDir()
+
+				cache, err := boost.NewFileCache(dir, boost.JSONCodec[string]{})
+				Expect(err).To(Succeed())
+				cache.Put("some/path", "digest")
+
+				reopened, err := boost.NewFileCache(dir, boost.JSONCodec[string]{})
+				Expect(err).To(Succeed())
+
+				payload, found := reopened.Get("some/path")
+				Expect(found).To(BeTrue())
+				Expect(payload).To(Equal("digest"))
+
+				_, found = reopened.Get("other")
+				Expect(found).To(BeFalse())
+				Expect(reopened.Err()).To(Succeed())
+			})
+		})
+	})
+})
@@ -2,8 +2,53 @@ package boost
 
 import (
 	"context"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
+// CancelCause denotes why a cancellation monitor invoked its callback.
+type CancelCause int
+
+const (
+	// CancelBySignal denotes that an os signal was received.
+	CancelBySignal CancelCause = iota + 1
+
+	// CancelBySendTimeout denotes that the pool signalled cancellation,
+	// because an output could not be sent within the timeout on send.
+	CancelBySendTimeout
+
+	// CancelByContext denotes that the context was cancelled.
+	CancelByContext
+)
+
+func (c CancelCause) String() string {
+	switch c {
+	case CancelBySignal:
+		return "signal"
+	case CancelBySendTimeout:
+		return "send-timeout"
+	case CancelByContext:
+		return "context"
+	}
+
+	return "unknown"
+}
+
+// CancelReason is passed to the callback of the signal monitor.
+type CancelReason struct {
+	// Cause denotes why the callback was invoked.
+	Cause CancelCause
+
+	// Signal is the signal received, when Cause is CancelBySignal.
+	Signal os.Signal
+
+	// Hard is false for the first signal received, at which point the
+	// client should drain the pool gracefully, eg by invoking Conclude
+	// or Shutdown. Otherwise, the context is being cancelled.
+	Hard bool
+}
+
 // StartCancellationMonitor
 func StartCancellationMonitor(ctx context.Context,
 	cancel context.CancelFunc,
@@ -27,3 +72,75 @@ func StartCancellationMonitor(ctx context.Context,
 		}
 	}(ctx, cancel, wg, cancelCh)
 }
+
+// StartSignalMonitor is the equivalent of StartCancellationMonitor that
+// also listens for the os signals specified, SIGINT and SIGTERM being the
+// default. The first signal received invokes the callback, so that the
+// client can drain the pool gracefully; a second signal escalates to a
+// hard cancel, invoking the callback again before cancelling the context.
+// The callback is also invoked when the pool signals cancellation via
+// cancelCh, which cancels the context, or when the context is cancelled,
+// at which point the monitor stops listening for signals and its go
+// routine exits.
+func StartSignalMonitor(ctx context.Context,
+	cancel context.CancelFunc,
+	wg WaitGroup,
+	cancelCh CancelStreamR,
+	on OnCancelReason,
+	signals ...os.Signal,
+) {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, signals...)
+
+	wg.Add(1)
+	go func(ctx context.Context,
+		cancel context.CancelFunc,
+		wg WaitGroup,
+		cancelCh CancelStreamR,
+	) {
+		defer wg.Done()
+		defer signal.Stop(signalCh)
+
+		draining := false
+
+		for {
+			select {
+			case sig := <-signalCh:
+				on(CancelReason{
+					Cause:  CancelBySignal,
+					Signal: sig,
+					Hard:   draining,
+				})
+
+				if draining {
+					cancel()
+
+					return
+				}
+
+				draining = true
+
+			case <-cancelCh:
+				on(CancelReason{
+					Cause: CancelBySendTimeout,
+					Hard:  true,
+				})
+				cancel()
+
+				return
+
+			case <-ctx.Done():
+				on(CancelReason{
+					Cause: CancelByContext,
+					Hard:  true,
+				})
+
+				return
+			}
+		}
+	}(ctx, cancel, wg, cancelCh)
+}
@@ -0,0 +1,91 @@
+package boost_test
+
+import (
+	"context"
+	"os"
+	"sync"
+	"syscall"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // ginkgo ok
+	. "github.com/onsi/gomega"    //nolint:revive // gomega ok
+
+	"github.com/snivilised/lorax/boost"
+)
+
+var _ = Describe("SignalMonitor", func() {
+	var (
+		wg        sync.WaitGroup
+		ctx       context.Context
+		cancel    context.CancelFunc
+		cancelCh  boost.CancelStream
+		reasonsCh chan boost.CancelReason
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		cancelCh = make(boost.CancelStream, 1)
+		reasonsCh = make(chan boost.CancelReason, 2)
+
+		boost.StartSignalMonitor(ctx, cancel, &wg, cancelCh,
+			func(reason boost.CancelReason) {
+				reasonsCh <- reason
+			},
+			syscall.SIGHUP,
+		)
+	})
+
+	AfterEach(func() {
+		cancel()
+		wg.Wait()
+	})
+
+	raise := func() {
+		process, err := os.FindProcess(os.Getpid())
+		Expect(err).To(Succeed())
+		Expect(process.Signal(syscall.SIGHUP)).To(Succeed())
+	}
+
+	When("signalled twice", func() {
+		It("🧪 should: drain then cancel", func() {
+			raise()
+
+			var reason boost.CancelReason
+			Eventually(reasonsCh).Should(Receive(&reason))
+			Expect(reason.Cause).To(Equal(boost.CancelBySignal))
+			Expect(reason.Signal).To(Equal(syscall.SIGHUP))
+			Expect(reason.Hard).To(BeFalse())
+			Consistently(ctx.Done()).ShouldNot(BeClosed())
+
+			raise()
+
+			Eventually(reasonsCh).Should(Receive(&reason))
+			Expect(reason.Cause).To(Equal(boost.CancelBySignal))
+			Expect(reason.Hard).To(BeTrue())
+			Eventually(ctx.Done()).Should(BeClosed())
+		})
+	})
+
+	When("timeout on send", func() {
+		It("🧪 should: cancel with send-timeout reason", func() {
+			cancelCh <- boost.CancelWorkSignal{}
+
+			var reason boost.CancelReason
+			Eventually(reasonsCh).Should(Receive(&reason))
+			Expect(reason.Cause).To(Equal(boost.CancelBySendTimeout))
+			Expect(reason.Cause.String()).To(Equal("send-timeout"))
+			Expect(reason.Hard).To(BeTrue())
+			Eventually(ctx.Done()).Should(BeClosed())
+		})
+	})
+
+	When("context cancelled", func() {
+		It("🧪 should: exit with context reason", func() {
+			cancel()
+			wg.Wait()
+
+			var reason boost.CancelReason
+			Expect(reasonsCh).To(Receive(&reason))
+			Expect(reason.Cause).To(Equal(boost.CancelByContext))
+		})
+	})
+})
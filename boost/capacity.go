@@ -0,0 +1,66 @@
+package boost
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"golang.org/x/sync/semaphore"
+
+	"github.com/snivilised/lorax/internal/ants"
+)
+
+// capacity bounds the total weight of the jobs in flight, as defined by
+// the capacity option; a job must acquire units of the weighted semaphore
+// equal to its weight, which it holds until its lifetime has ended.
+type capacity struct {
+	total       int64
+	nonblocking bool
+	sem         *semaphore.Weighted
+	inUse       atomic.Int64
+}
+
+func newCapacity(o *Options) *capacity {
+	if o.Capacity <= 0 {
+		return nil
+	}
+
+	return &capacity{
+		total:       o.Capacity,
+		nonblocking: o.Nonblocking,
+		sem:         semaphore.NewWeighted(o.Capacity),
+	}
+}
+
+// acquire blocks until the weight is available, or the context is
+// cancelled. In non-blocking mode, ErrPoolOverload is returned instead
+// of waiting.
+func (c *capacity) acquire(ctx context.Context, weight int64) error {
+	weight = max(weight, 1)
+
+	if weight > c.total {
+		return fmt.Errorf("%w (weight: '%v', capacity: '%v')",
+			ErrOverweight, weight, c.total,
+		)
+	}
+
+	if c.nonblocking {
+		if !c.sem.TryAcquire(weight) {
+			return ants.ErrPoolOverload
+		}
+	} else if err := c.sem.Acquire(ctx, weight); err != nil {
+		return err
+	}
+
+	c.inUse.Add(weight)
+
+	return nil
+}
+
+// release returns the weight previously acquired.
+func (c *capacity) release(weight int64) {
+	weight = max(weight, 1)
+
+	c.inUse.Add(-weight)
+	c.sem.Release(weight)
+}
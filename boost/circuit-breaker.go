@@ -0,0 +1,185 @@
+package boost
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// change is a transition of the circuit, pending notification.
+	change struct {
+		from, to CircuitState
+	}
+
+	// breaker implements the circuit breaker option. Each change of state
+	// starts a new generation, so that the results of jobs admitted in a
+	// previous generation are disregarded.
+	breaker struct {
+		mutex      sync.Mutex
+		options    CircuitBreaker
+		state      CircuitState
+		generation uint64
+		expiry     time.Time
+		requests   uint
+		failures   uint
+		successive uint
+		successes  uint
+		probes     uint
+		changes    []change
+	}
+)
+
+func newBreaker(o *Options) *breaker {
+	if o.CircuitBreaker == nil {
+		return nil
+	}
+
+	b := &breaker{
+		options: *o.CircuitBreaker,
+	}
+
+	b.options.HalfOpenProbes = max(b.options.HalfOpenProbes, 1)
+	b.renew(time.Now())
+
+	return b
+}
+
+// allow determines whether a job can be executed, returning the
+// generation in which it was admitted.
+func (b *breaker) allow() (generation uint64, allowed bool) {
+	b.mutex.Lock()
+	b.refresh(time.Now())
+
+	switch b.state {
+	case CircuitOpen:
+		allowed = false
+
+	case CircuitHalfOpen:
+		if allowed = b.probes < b.options.HalfOpenProbes; allowed {
+			b.probes++
+		}
+
+	case CircuitClosed:
+		allowed = true
+	}
+
+	generation = b.generation
+	b.unlock()
+
+	return generation, allowed
+}
+
+// record registers the result of a job admitted in the generation.
+func (b *breaker) record(generation uint64, err error) {
+	b.mutex.Lock()
+	defer b.unlock()
+
+	now := time.Now()
+	b.refresh(now)
+
+	if generation != b.generation {
+		return
+	}
+
+	switch b.state {
+	case CircuitHalfOpen:
+		if err != nil {
+			b.transition(CircuitOpen, now)
+
+			return
+		}
+
+		if b.successes++; b.successes >= b.options.HalfOpenProbes {
+			b.transition(CircuitClosed, now)
+		}
+
+	case CircuitClosed:
+		b.requests++
+		b.successive++
+
+		if err == nil {
+			b.successive = 0
+		} else {
+			b.failures++
+		}
+
+		if b.tripped() {
+			b.transition(CircuitOpen, now)
+		}
+
+	case CircuitOpen:
+	}
+}
+
+// tripped evaluates the thresholds whilst the circuit is closed.
+func (b *breaker) tripped() bool {
+	if n := b.options.ConsecutiveFailures; n > 0 && b.successive >= n {
+		return true
+	}
+
+	if rate := b.options.FailureRate; rate > 0 && b.requests >= max(b.options.MinRequests, 1) {
+		return float64(b.failures)/float64(b.requests) >= rate
+	}
+
+	return false
+}
+
+// refresh performs the transitions that are due to the passing of time.
+func (b *breaker) refresh(now time.Time) {
+	if b.expiry.IsZero() || now.Before(b.expiry) {
+		return
+	}
+
+	switch b.state {
+	case CircuitOpen:
+		b.transition(CircuitHalfOpen, now)
+
+	case CircuitClosed:
+		b.renew(now)
+
+	case CircuitHalfOpen:
+	}
+}
+
+func (b *breaker) transition(to CircuitState, now time.Time) {
+	b.changes = append(b.changes, change{from: b.state, to: to})
+	b.state = to
+	b.renew(now)
+}
+
+// renew starts a new generation in the current state.
+func (b *breaker) renew(now time.Time) {
+	b.generation++
+	b.requests, b.failures, b.successive, b.successes, b.probes = 0, 0, 0, 0, 0
+
+	switch b.state {
+	case CircuitOpen:
+		b.expiry = now.Add(b.options.CoolDown)
+
+	case CircuitClosed:
+		b.expiry = time.Time{}
+
+		if b.options.Interval > 0 {
+			b.expiry = now.Add(b.options.Interval)
+		}
+
+	case CircuitHalfOpen:
+		b.expiry = time.Time{}
+	}
+}
+
+// unlock releases the mutex and then notifies the client of any changes
+// of state, so that the callback is free to interact with the pool.
+func (b *breaker) unlock() {
+	changes := b.changes
+	b.changes = nil
+	b.mutex.Unlock()
+
+	if b.options.OnStateChange == nil {
+		return
+	}
+
+	for _, c := range changes {
+		b.options.OnStateChange(c.from, c.to)
+	}
+}
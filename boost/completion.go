@@ -0,0 +1,78 @@
+package boost
+
+import (
+	"errors"
+	"sync"
+)
+
+// completion aggregates the errors of all jobs executed by a pool, so
+// that a single PoolResult can be delivered to the client via the
+// completion stream, once the pool has finished.
+type completion struct {
+	mutex       sync.Mutex
+	errs        []error
+	first       *JobError
+	done        bool
+	resultDupCh *Duplex[*PoolResult]
+}
+
+func newCompletion() completion {
+	return completion{
+		resultDupCh: NewDuplex(make(PoolResultStream, 1)),
+	}
+}
+
+// report records the error returned by a job, if there is one.
+func (c *completion) report(id string, sequenceNo int, err error) {
+	if err == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.done {
+		return
+	}
+
+	je := &JobError{
+		ID:         id,
+		SequenceNo: sequenceNo,
+		Err:        err,
+	}
+
+	if c.first == nil {
+		c.first = je
+	}
+
+	c.errs = append(c.errs, je)
+}
+
+// complete sends the PoolResult on the completion stream, then closes it.
+// A non nil err denotes that the pool ended prematurely.
+func (c *completion) complete(err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.done {
+		return
+	}
+
+	c.done = true
+
+	if err != nil {
+		c.errs = append(c.errs, err)
+	}
+
+	c.resultDupCh.WriterCh <- &PoolResult{
+		Error:        errors.Join(c.errs...),
+		FirstFailure: c.first,
+	}
+	close(c.resultDupCh.Channel)
+}
+
+// Completion returns the stream on which the PoolResult is delivered
+// once the pool has finished.
+func (c *completion) Completion() PoolResultStreamR {
+	return c.resultDupCh.ReaderCh
+}
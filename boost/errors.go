@@ -0,0 +1,145 @@
+package boost
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// JobError identifies the job that failed, along with the error that
+// it returned.
+type JobError struct {
+	ID         string
+	SequenceNo int
+	Err        error
+}
+
+func (e *JobError) Error() string {
+	return fmt.Sprintf("job (id: '%v', seq: '%v') failed: %v",
+		e.ID, e.SequenceNo, e.Err,
+	)
+}
+
+func (e *JobError) Unwrap() error {
+	return e.Err
+}
+
+// ErrJobTimeout can be used with errors.Is to determine whether a job
+// overran its deadline.
+var ErrJobTimeout = errors.New("job timed out")
+
+// JobTimeoutError is the error reported in the JobOutput of a job that
+// overran its deadline, either the one defined on the job or the one
+// implied by the per-job timeout option.
+type JobTimeoutError struct {
+	ID         string
+	SequenceNo int
+	Deadline   time.Time
+}
+
+func (e *JobTimeoutError) Error() string {
+	return fmt.Sprintf("job (id: '%v', seq: '%v') timed out (deadline: '%v')",
+		e.ID, e.SequenceNo, e.Deadline.Format(time.RFC3339Nano),
+	)
+}
+
+func (e *JobTimeoutError) Is(target error) bool {
+	return target == ErrJobTimeout
+}
+
+// ErrJobPanic can be used with errors.Is to determine whether a job
+// panicked.
+var ErrJobPanic = errors.New("job panicked")
+
+// PanicError is the error reported in the JobOutput of a job that
+// panicked, when the pool has been configured to recover panics, or in
+// the PoolResult of an error returning pool, which always does. It
+// contains the recovered value along with the stack trace captured at
+// the point of recovery.
+type PanicError struct {
+	ID         string
+	SequenceNo int
+	Value      interface{}
+	Stack      []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("job (id: '%v', seq: '%v') panicked: %v",
+		e.ID, e.SequenceNo, e.Value,
+	)
+}
+
+func (e *PanicError) Is(target error) bool {
+	return target == ErrJobPanic
+}
+
+// ErrRateLimited can be used with errors.Is to determine whether a job
+// was rejected because no token was available.
+var ErrRateLimited = errors.New("rate limited")
+
+// RateLimitError is returned when posting a job to a pool whose rate
+// limit is non-blocking and no token is currently available.
+type RateLimitError struct {
+	// Key is the key of the job, if any, as derived by the key function.
+	Key string
+
+	// RetryAfter is how long it will be until a token is available.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("rate limited, retry after: '%v'", e.RetryAfter)
+	}
+
+	return fmt.Sprintf("rate limited (key: '%v'), retry after: '%v'",
+		e.Key, e.RetryAfter,
+	)
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// ErrCircuitOpen is the error reported in the JobOutput of a job that was
+// not executed because the circuit breaker was open.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// ErrJournalCorrupt is returned by OpenJournal when the journal contains
+// a record that can not be decoded.
+var ErrJournalCorrupt = errors.New("journal corrupt")
+
+// ErrBatchMismatch is the error reported in the JobOutput of every job in
+// a batch, when the batch function returns a different number of outputs
+// to the number of inputs it was given.
+var ErrBatchMismatch = errors.New("batch output count mismatch")
+
+// ErrOptionType is returned by the constructor of a pool when a typed
+// option, such as WithKeyFunc, has been defined with type parameters that
+// do not match those of the pool, so it would otherwise be ignored.
+var ErrOptionType = errors.New("option type mismatch")
+
+// ErrOptionUnsupported is returned by the constructor of a pool when an
+// option has been defined that the pool does not honour, such as
+// WithCapacity for a pool whose jobs can not be posted with a Weight, or
+// WithMiddleware for a pool whose jobs are opaque or executed in batches.
+var ErrOptionUnsupported = errors.New("option not supported by pool")
+
+// ErrOverweight is returned when a job is posted whose weight exceeds
+// the capacity of the pool, so it could never be admitted.
+var ErrOverweight = errors.New("job weight exceeds capacity")
+
+// StageError is the error reported in the output of a pipeline, for a job
+// that failed at one of its stages, numbered from 1.
+type StageError struct {
+	Stage int
+	Err   error
+}
+
+func (e *StageError) Error() string {
+	return fmt.Sprintf("pipeline stage '%v' failed: %v", e.Stage, e.Err)
+}
+
+func (e *StageError) Unwrap() error {
+	return e.Err
+}
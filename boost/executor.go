@@ -0,0 +1,242 @@
+package boost
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+)
+
+// executor is responsible for executing jobs and delivering their
+// outputs to the output stream, if the pool has been configured with one.
+type executor[I, O any] struct {
+	timeout       time.Duration
+	recoverPanics bool
+	retrier       *retrier
+	breaker       *breaker
+	flights       *flights[I, O]
+	memo          *memo[I, O]
+	middleware    []Middleware[I, O]
+	wi            *outputInfoW[O]
+	stats         *collector
+}
+
+func newExecutor[I, O any](o *Options,
+	wi *outputInfoW[O],
+	stats *collector,
+) *executor[I, O] {
+	return &executor[I, O]{
+		timeout:       o.JobTimeout,
+		recoverPanics: o.RecoverPanics,
+		retrier:       newRetrier(o),
+		breaker:       newBreaker(o),
+		flights:       newFlights[I, O](o),
+		memo:          memoFrom[I, O](o),
+		middleware:    middlewareFrom[I, O](o),
+		wi:            wi,
+		stats:         stats,
+	}
+}
+
+// run executes the job and sends its output. Whilst the circuit breaker,
+// if defined, is open, the job is not executed; rather it is rejected
+// with ErrCircuitOpen.
+func (e *executor[I, O]) run(ctx context.Context,
+	fn ManifoldFuncCtx[I, O],
+	job Job[I],
+) {
+	var (
+		generation uint64
+		settled    bool
+	)
+
+	if e.breaker != nil {
+		var allowed bool
+
+		if generation, allowed = e.breaker.allow(); !allowed {
+			e.reject(ctx, &job, ErrCircuitOpen)
+
+			return
+		}
+
+		// a job that panics without being recovered must still be recorded,
+		// otherwise it would hold on to a half-open probe indefinitely.
+		defer func() {
+			if !settled {
+				e.breaker.record(generation, ErrJobPanic)
+			}
+		}()
+	}
+
+	var output JobOutput[O]
+
+	_ = e.stats.measure(job.posted, func() error {
+		output = e.handle(ctx, fn, job)
+
+		return output.Error
+	})
+
+	if e.breaker != nil {
+		e.breaker.record(generation, output.Error)
+		settled = true
+	}
+
+	if e.memo != nil && output.Error == nil {
+		e.memo.put(job.Input, output.Payload)
+	}
+
+	if e.wi != nil {
+		_ = respond(ctx, e.wi, &output)
+	}
+}
+
+// reject sends an output for a job that could not be executed, carrying
+// the reason as its error.
+func (e *executor[I, O]) reject(ctx context.Context, job *Job[I], err error) {
+	e.stats.outcome(err)
+
+	if e.wi != nil {
+		_ = respond(ctx, e.wi, &JobOutput[O]{
+			ID:         job.ID,
+			SequenceNo: job.SequenceNo,
+			Error:      err,
+		})
+	}
+}
+
+// recall sends an output for a job whose payload was found in the result
+// cache, so the job did not need to be executed.
+func (e *executor[I, O]) recall(ctx context.Context, job *Job[I], payload O) {
+	e.stats.outcome(nil)
+
+	if e.wi != nil {
+		_ = respond(ctx, e.wi, &JobOutput[O]{
+			ID:         job.ID,
+			SequenceNo: job.SequenceNo,
+			Payload:    payload,
+		})
+	}
+}
+
+// handle executes the job, via the chain of middleware, if defined.
+func (e *executor[I, O]) handle(ctx context.Context,
+	fn ManifoldFuncCtx[I, O],
+	job Job[I],
+) JobOutput[O] {
+	if len(e.middleware) == 0 {
+		return e.share(ctx, fn, &job)
+	}
+
+	return intercept(e.middleware, func(ctx context.Context, job Job[I]) JobOutput[O] {
+		return e.share(ctx, fn, &job)
+	})(ctx, job)
+}
+
+// share executes the job, unless deduplication is enabled and a job with
+// the same key is already executing, in which case its output is shared.
+func (e *executor[I, O]) share(ctx context.Context,
+	fn ManifoldFuncCtx[I, O],
+	job *Job[I],
+) JobOutput[O] {
+	if e.flights == nil {
+		return e.execute(ctx, fn, *job)
+	}
+
+	return e.flights.do(ctx, job, func() JobOutput[O] {
+		return e.execute(ctx, fn, *job)
+	})
+}
+
+// execute invokes the function, as many times as allowed by the retry
+// policy, returning the output of the final attempt.
+func (e *executor[I, O]) execute(ctx context.Context,
+	fn ManifoldFuncCtx[I, O],
+	job Job[I],
+) JobOutput[O] {
+	var output JobOutput[O]
+
+	output.Attempts, _ = e.retrier.do(ctx, func() error {
+		output = e.attempt(ctx, fn, job)
+
+		return output.Error
+	})
+
+	return output
+}
+
+// attempt invokes the function with a context that is bound by the
+// job's effective deadline. A job that overruns its deadline results
+// in a JobTimeoutError, regardless of whatever else the function returned.
+// When panic recovery is enabled, a panicking job results in a PanicError
+// instead of the panic propagating to the worker.
+func (e *executor[I, O]) attempt(ctx context.Context,
+	fn ManifoldFuncCtx[I, O],
+	job Job[I],
+) (output JobOutput[O]) {
+	if e.recoverPanics {
+		defer func() {
+			if pv := recover(); pv != nil {
+				output = JobOutput[O]{
+					ID:         job.ID,
+					SequenceNo: job.SequenceNo,
+					Error: &PanicError{
+						ID:         job.ID,
+						SequenceNo: job.SequenceNo,
+						Value:      pv,
+						Stack:      debug.Stack(),
+					},
+				}
+			}
+		}()
+	}
+
+	deadline := e.deadline(&job)
+	jctx, cancel := ctx, context.CancelFunc(func() {})
+
+	if !deadline.IsZero() {
+		jctx, cancel = context.WithDeadline(ctx, deadline)
+	}
+	defer cancel()
+
+	payload, err := fn(jctx, job.Input)
+
+	if !deadline.IsZero() && !time.Now().Before(deadline) && ctx.Err() == nil {
+		var zero O
+
+		payload, err = zero, &JobTimeoutError{
+			ID:         job.ID,
+			SequenceNo: job.SequenceNo,
+			Deadline:   deadline,
+		}
+	}
+
+	return JobOutput[O]{
+		ID:         job.ID,
+		SequenceNo: job.SequenceNo,
+		Payload:    payload,
+		Error:      err,
+	}
+}
+
+// deadline returns the earliest of the job's own deadline and the
+// deadline implied by the per-job timeout, measured from the start of
+// the current attempt. A zero
+// time denotes the job is not subject to a deadline.
+func (e *executor[I, O]) deadline(job *Job[I]) time.Time {
+	deadline := job.Deadline
+
+	if e.timeout > 0 {
+		if bound := time.Now().Add(e.timeout); deadline.IsZero() || bound.Before(deadline) {
+			deadline = bound
+		}
+	}
+
+	return deadline
+}
+
+// withContext adapts a ManifoldFunc into a ManifoldFuncCtx, which simply
+// ignores the context.
+func withContext[I, O any](mf ManifoldFunc[I, O]) ManifoldFuncCtx[I, O] {
+	return func(_ context.Context, input I) (O, error) {
+		return mf(input)
+	}
+}
@@ -134,6 +134,10 @@ func respond[O any](ctx context.Context, wi *outputInfoW[O], output *JobOutput[O
 	case wi.outputCh <- *output:
 		return nil
 	case <-time.After(wi.timeoutOnSend):
+		if wi.stats != nil {
+			wi.stats.sendTimeout()
+		}
+
 		select {
 		case <-ctx.Done():
 			err = ctx.Err()
@@ -0,0 +1,291 @@
+package boost
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+type (
+	// Codec encodes and decodes job inputs, so that they can be recorded
+	// in a journal.
+	Codec[I any] interface {
+		Encode(input I) ([]byte, error)
+		Decode(data []byte) (I, error)
+	}
+
+	// JSONCodec is a Codec that encodes inputs as json.
+	JSONCodec[I any] struct{}
+
+	// journalOp denotes the kind of a journal record.
+	journalOp string
+
+	// journalRecord is a single line of the journal. Jobs are identified
+	// by their entry, which unlike the job's ID and SequenceNo, remains
+	// the same across runs.
+	journalRecord struct {
+		Op         journalOp `json:"op"`
+		Entry      uint64    `json:"entry"`
+		ID         string    `json:"id,omitempty"`
+		SequenceNo int       `json:"seq,omitempty"`
+		Priority   int       `json:"priority,omitempty"`
+		Input      []byte    `json:"input,omitempty"`
+	}
+
+	// Journal is a write-ahead log of the jobs posted to a pool, backed
+	// by a local file. A record is written for each job before it is
+	// dispatched and another once its output has been delivered, or it
+	// has been rejected, so that the jobs which were incomplete at the time of a crash can be
+	// re-submitted by ResumeManifoldFuncPool. Since a job may complete
+	// without its completion having been recorded, jobs are executed at
+	// least once, rather than exactly once.
+	Journal[I any] struct {
+		mutex      sync.Mutex
+		path       string
+		file       *os.File
+		codec      Codec[I]
+		entry      uint64
+		incomplete []Job[I]
+		err        error
+	}
+)
+
+const (
+	journalPost journalOp = "post"
+	journalDone journalOp = "done"
+)
+
+func (JSONCodec[I]) Encode(input I) ([]byte, error) {
+	return json.Marshal(input)
+}
+
+func (JSONCodec[I]) Decode(data []byte) (I, error) {
+	var input I
+	err := json.Unmarshal(data, &input)
+
+	return input, err
+}
+
+// OpenJournal opens the journal at path, creating it if it does not
+// exist. The jobs recorded in an existing journal that never completed
+// are loaded, after which the journal is compacted so that it only
+// retains those jobs. A record truncated by a crash, which can only be
+// the last, is discarded.
+func OpenJournal[I any](path string, codec Codec[I]) (*Journal[I], error) {
+	j := &Journal[I]{
+		path:  path,
+		codec: codec,
+	}
+
+	records, err := j.load()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := j.compact(records); err != nil {
+		return nil, err
+	}
+
+	return j, nil
+}
+
+// Incomplete returns the jobs that had not completed when the journal
+// was opened, in the order in which they were originally posted.
+func (j *Journal[I]) Incomplete() []Job[I] {
+	return j.incomplete
+}
+
+// Close closes the journal, returning the first error that occurred
+// whilst recording jobs, if any.
+func (j *Journal[I]) Close() error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if err := j.file.Close(); err != nil && j.err == nil {
+		j.err = err
+	}
+
+	return j.err
+}
+
+// load reads the existing journal, returning the post records of the
+// jobs that never completed, in order of entry.
+func (j *Journal[I]) load() ([]journalRecord, error) {
+	file, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	posted := make(map[uint64]journalRecord)
+	reader := bufio.NewReader(file)
+
+	for line := 1; ; line++ {
+		data, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// a line without a terminator is a torn write
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		var record journalRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, fmt.Errorf("%w (line: '%v'): %w", ErrJournalCorrupt, line, err)
+		}
+
+		j.entry = max(j.entry, record.Entry)
+
+		switch record.Op {
+		case journalPost:
+			posted[record.Entry] = record
+		case journalDone:
+			delete(posted, record.Entry)
+		}
+	}
+
+	records := make([]journalRecord, 0, len(posted))
+	for _, record := range posted {
+		records = append(records, record)
+	}
+
+	sort.Slice(records, func(a, b int) bool {
+		return records[a].Entry < records[b].Entry
+	})
+
+	return records, nil
+}
+
+// compact rewrites the journal so that it contains only the records
+// provided, then opens it for appending.
+func (j *Journal[I]) compact(records []journalRecord) error {
+	j.incomplete = make([]Job[I], 0, len(records))
+	temp := j.path + ".tmp"
+
+	file, err := os.Create(temp)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+
+	for _, record := range records {
+		input, err := j.codec.Decode(record.Input)
+		if err != nil {
+			file.Close()
+
+			return fmt.Errorf("%w (entry: '%v'): %w", ErrJournalCorrupt, record.Entry, err)
+		}
+
+		if err := encoder.Encode(record); err != nil {
+			file.Close()
+
+			return err
+		}
+
+		j.incomplete = append(j.incomplete, Job[I]{
+			ID:         record.ID,
+			SequenceNo: record.SequenceNo,
+			Input:      input,
+			Priority:   record.Priority,
+			entry:      record.Entry,
+		})
+	}
+
+	err = writer.Flush()
+	if err == nil {
+		err = file.Sync()
+	}
+
+	if e := file.Close(); err == nil {
+		err = e
+	}
+
+	if err == nil {
+		err = os.Rename(temp, j.path)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	j.file, err = os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND, 0)
+
+	return err
+}
+
+// posted records the job before it is dispatched, syncing the record to
+// storage. A job that has been replayed from the journal has already been
+// recorded, so is not recorded again.
+func (j *Journal[I]) posted(job *Job[I]) error {
+	if job.entry != 0 {
+		return nil
+	}
+
+	data, err := j.codec.Encode(job.Input)
+	if err != nil {
+		return err
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.entry++
+	job.entry = j.entry
+
+	if err := j.write(journalRecord{
+		Op:         journalPost,
+		Entry:      job.entry,
+		ID:         job.ID,
+		SequenceNo: job.SequenceNo,
+		Priority:   job.Priority,
+		Input:      data,
+	}); err != nil {
+		return err
+	}
+
+	// the job must not be dispatched until its record is durable, otherwise
+	// a crash could lose a job that was accepted. A lost completion record
+	// merely results in the job being executed again, so is not synced.
+	return j.file.Sync()
+}
+
+// completed records that the output of the job has been delivered.
+// Since there is no client to report to at this point, the first
+// failure to record a completion is reported by Close.
+func (j *Journal[I]) completed(job *Job[I]) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if err := j.write(journalRecord{
+		Op:    journalDone,
+		Entry: job.entry,
+	}); err != nil && j.err == nil {
+		j.err = err
+	}
+}
+
+// write appends the record to the journal as a single line, in a single
+// write, so that a crash can only ever tear the last record.
+func (j *Journal[I]) write(record journalRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	_, err = j.file.Write(append(data, '\n'))
+
+	return err
+}
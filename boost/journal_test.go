@@ -0,0 +1,240 @@
+package boost_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // ginkgo ok
+	. "github.com/onsi/gomega"    //nolint:revive // gomega ok
+
+	"github.com/snivilised/lorax/boost"
+	"github.com/snivilised/lorax/internal/ants"
+)
+
+var _ = Describe("Journal", func() {
+	var (
+		path  string
+		codec boost.JSONCodec[int]
+	)
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "jobs.journal")
+	})
+
+	inputs := func(jobs []boost.Job[int]) []int {
+		result := make([]int, 0, len(jobs))
+		for _, job := range jobs {
+			result = append(result, job.Input)
+		}
+
+		return result
+	}
+
+	When("pool did not complete all jobs", func() {
+		It("🧪 should: resume incomplete jobs", func(specCtx SpecContext) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			journal, err := boost.OpenJournal(path, codec)
+			Expect(err).To(Succeed())
+			Expect(journal.Incomplete()).To(BeEmpty())
+
+			started := make(chan struct{})
+			release := make(chan struct{})
+			pool, err := boost.NewManifoldFuncPool(
+				ctx, func(input int) (int, error) {
+					if input == 0 {
+						close(started)
+						<-release
+					}
+
+					return input, nil
+				}, &wg,
+				boost.WithSize(1),
+				boost.WithOutput(10, 0, TimeoutOnSend),
+				boost.WithJournal(journal),
+			)
+			Expect(err).To(Succeed())
+
+			Expect(pool.Post(ctx, 0)).To(Succeed())
+			<-started
+
+			for i := 1; i <= 2; i++ {
+				go func(input int) {
+					_ = pool.Post(ctx, input)
+				}(i)
+				Eventually(pool.Waiting).Should(Equal(i))
+			}
+
+			time.AfterFunc(time.Millisecond*20, func() {
+				close(release)
+			})
+			unstarted, err := pool.Shutdown(ctx, time.Second)
+			Expect(err).To(Succeed())
+			Expect(inputs(unstarted)).To(Equal([]int{1, 2}))
+			Expect(journal.Close()).To(Succeed())
+
+			By("👾 RESUMING\n")
+			journal, err = boost.OpenJournal(path, codec)
+			Expect(err).To(Succeed())
+			Expect(inputs(journal.Incomplete())).To(Equal([]int{1, 2}))
+
+			resumed, err := boost.ResumeManifoldFuncPool(
+				ctx, func(input int) (int, error) {
+					return input * 10, nil
+				}, &wg, journal,
+				boost.WithSize(1),
+				boost.WithOutput(10, 0, TimeoutOnSend),
+			)
+			Expect(err).To(Succeed())
+			defer resumed.Release(ctx)
+
+			Expect(resumed.Post(ctx, 3)).To(Succeed())
+			resumed.Conclude(ctx)
+
+			var payloads []int
+			for output := range resumed.Observe() {
+				payloads = append(payloads, output.Payload)
+			}
+			Expect(payloads).To(ConsistOf(10, 20, 30))
+			Expect(journal.Close()).To(Succeed())
+
+			journal, err = boost.OpenJournal(path, codec)
+			Expect(err).To(Succeed())
+			Expect(journal.Incomplete()).To(BeEmpty())
+			Expect(journal.Close()).To(Succeed())
+			wg.Wait()
+		})
+	})
+
+	When("pool rejects jobs", func() {
+		It("🧪 should: not resume rejected jobs", func(specCtx SpecContext) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			journal, err := boost.OpenJournal(path, codec)
+			Expect(err).To(Succeed())
+
+			started := make(chan struct{})
+			release := make(chan struct{})
+			pool, err := boost.NewManifoldFuncPool(
+				ctx, func(input int) (int, error) {
+					if input == 0 {
+						close(started)
+						<-release
+					}
+
+					return input, nil
+				}, &wg,
+				boost.WithSize(1),
+				boost.WithNonblocking(true),
+				boost.WithOutput(10, 0, TimeoutOnSend),
+				boost.WithJournal(journal),
+			)
+			Expect(err).To(Succeed())
+			defer pool.Release(ctx)
+
+			Expect(pool.Post(ctx, 0)).To(Succeed())
+			<-started
+
+			for i := 1; i <= 2; i++ {
+				Expect(pool.Post(ctx, i)).To(MatchError(ants.ErrPoolOverload))
+			}
+
+			close(release)
+			pool.Conclude(ctx)
+
+			for range pool.Observe() {
+			}
+			Expect(journal.Close()).To(Succeed())
+
+			journal, err = boost.OpenJournal(path, codec)
+			Expect(err).To(Succeed())
+			Expect(journal.Incomplete()).To(BeEmpty())
+			Expect(journal.Close()).To(Succeed())
+			wg.Wait()
+		})
+	})
+
+	DescribeTable("🧪 should: be rejected by pools that do not record jobs",
+		func(specCtx SpecContext, create func(ctx context.Context, wg *sync.WaitGroup, journal *boost.Journal[int]) error) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			journal, err := boost.OpenJournal(path, codec)
+			Expect(err).To(Succeed())
+			defer journal.Close()
+
+			Expect(create(ctx, &wg, journal)).To(MatchError(boost.ErrOptionUnsupported))
+		},
+		Entry("ManifoldTaskPool", func(ctx context.Context, wg *sync.WaitGroup, journal *boost.Journal[int]) error {
+			_, err := boost.NewManifoldTaskPool[int, int](ctx, wg, boost.WithJournal(journal))
+
+			return err
+		}),
+		Entry("MultiManifoldFuncPool", func(ctx context.Context, wg *sync.WaitGroup, journal *boost.Journal[int]) error {
+			_, err := boost.NewMultiManifoldFuncPool(
+				ctx, demoPoolManifoldFunc, wg, 2, boost.RoundRobin, boost.WithJournal(journal),
+			)
+
+			return err
+		}),
+		Entry("FuncPoolE", func(ctx context.Context, wg *sync.WaitGroup, journal *boost.Journal[int]) error {
+			_, err := boost.NewFuncPoolE(ctx, failOdd, wg, boost.WithJournal(journal))
+
+			return err
+		}),
+		Entry("TaskPoolE", func(ctx context.Context, wg *sync.WaitGroup, journal *boost.Journal[int]) error {
+			_, err := boost.NewTaskPoolE[int](ctx, wg, boost.WithJournal(journal))
+
+			return err
+		}),
+		Entry("TaskPool", func(ctx context.Context, wg *sync.WaitGroup, journal *boost.Journal[int]) error {
+			_, err := boost.NewTaskPool[int, int](ctx, wg, boost.WithJournal(journal))
+
+			return err
+		}),
+		Entry("ManifoldBatchFuncPool", func(ctx context.Context, wg *sync.WaitGroup, journal *boost.Journal[int]) error {
+			_, err := boost.NewManifoldBatchFuncPool(ctx, func(inputs []int) ([]int, error) {
+				return inputs, nil
+			}, wg, boost.WithJournal(journal))
+
+			return err
+		}),
+	)
+
+	When("last record is torn", func() {
+		It("🧪 should: discard record", func() {
+			Expect(os.WriteFile(path, []byte(
+				`{"op":"post","entry":1,"id":"ID:00000001","seq":1,"input":"NDI="}`+"\n"+
+					`{"op":"post","entry":2,"id":"ID:0000`,
+			), 0o600)).To(Succeed())
+
+			journal, err := boost.OpenJournal(path, codec)
+			Expect(err).To(Succeed())
+			defer journal.Close()
+
+			Expect(inputs(journal.Incomplete())).To(Equal([]int{42}))
+		})
+	})
+
+	When("record is corrupt", func() {
+		It("🧪 should: fail to open", func() {
+			Expect(os.WriteFile(path, []byte(
+				"garbage\n"+`{"op":"done","entry":1}`+"\n",
+			), 0o600)).To(Succeed())
+
+			_, err := boost.OpenJournal(path, codec)
+			Expect(err).To(MatchError(boost.ErrJournalCorrupt))
+		})
+	})
+})
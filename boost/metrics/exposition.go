@@ -0,0 +1,205 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/snivilised/lorax/boost"
+)
+
+// ContentType is the content type of the Prometheus text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+const (
+	counter = "counter"
+	gauge   = "gauge"
+	hist    = "histogram"
+)
+
+// family describes a metric family, which is rendered with a sample for
+// each registered pool. Scalar families define value, histogram families
+// define histogram.
+type family struct {
+	name      string
+	help      string
+	kind      string
+	value     func(s *boost.PoolStats) float64
+	histogram func(s *boost.PoolStats) *boost.Histogram
+}
+
+var families = []family{
+	{
+		name: "lorax_pool_running_workers",
+		help: "Number of workers currently running.",
+		kind: gauge,
+		value: func(s *boost.PoolStats) float64 {
+			return float64(s.Running)
+		},
+	},
+	{
+		name: "lorax_pool_waiting_tasks",
+		help: "Number of tasks blocked waiting for a worker.",
+		kind: gauge,
+		value: func(s *boost.PoolStats) float64 {
+			return float64(s.Waiting)
+		},
+	},
+	{
+		name: "lorax_pool_jobs_submitted_total",
+		help: "Number of jobs accepted by the pool.",
+		kind: counter,
+		value: func(s *boost.PoolStats) float64 {
+			return float64(s.Submitted)
+		},
+	},
+	{
+		name: "lorax_pool_jobs_completed_total",
+		help: "Number of jobs that executed without error.",
+		kind: counter,
+		value: func(s *boost.PoolStats) float64 {
+			return float64(s.Completed)
+		},
+	},
+	{
+		name: "lorax_pool_jobs_failed_total",
+		help: "Number of jobs that resulted in an error.",
+		kind: counter,
+		value: func(s *boost.PoolStats) float64 {
+			return float64(s.Failed)
+		},
+	},
+	{
+		name: "lorax_pool_jobs_panicked_total",
+		help: "Number of jobs that panicked.",
+		kind: counter,
+		value: func(s *boost.PoolStats) float64 {
+			return float64(s.Panicked)
+		},
+	},
+	{
+		name: "lorax_pool_jobs_timed_out_total",
+		help: "Number of jobs that overran their deadline.",
+		kind: counter,
+		value: func(s *boost.PoolStats) float64 {
+			return float64(s.TimedOut)
+		},
+	},
+	{
+		name: "lorax_pool_send_timeouts_total",
+		help: "Number of outputs that timed out whilst being sent.",
+		kind: counter,
+		value: func(s *boost.PoolStats) float64 {
+			return float64(s.SendTimeouts)
+		},
+	},
+	{
+		name: "lorax_pool_workers_spawned_total",
+		help: "Number of worker go routines started.",
+		kind: counter,
+		value: func(s *boost.PoolStats) float64 {
+			return float64(s.Spawned)
+		},
+	},
+	{
+		name: "lorax_pool_workers_purged_total",
+		help: "Number of idle workers cleared by the purge loop.",
+		kind: counter,
+		value: func(s *boost.PoolStats) float64 {
+			return float64(s.Purged)
+		},
+	},
+	{
+		name: "lorax_pool_queue_wait_seconds",
+		help: "Time jobs spent waiting between being posted and starting execution.",
+		kind: hist,
+		histogram: func(s *boost.PoolStats) *boost.Histogram {
+			return &s.QueueWait
+		},
+	},
+	{
+		name: "lorax_pool_execution_seconds",
+		help: "Time jobs spent executing.",
+		kind: hist,
+		histogram: func(s *boost.PoolStats) *boost.Histogram {
+			return &s.Execution
+		},
+	},
+}
+
+// ServeHTTP renders the metrics of all registered pools, so that the
+// registry can be mounted directly as the scrape endpoint.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", ContentType)
+	_, _ = r.WriteTo(w)
+}
+
+// WriteTo renders the metrics of all registered pools to w, in the
+// Prometheus text exposition format.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	var (
+		buf     bytes.Buffer
+		samples = r.gather()
+	)
+
+	for i := range families {
+		render(&buf, &families[i], samples)
+	}
+
+	return buf.WriteTo(w)
+}
+
+func render(buf *bytes.Buffer, f *family, samples []sample) {
+	fmt.Fprintf(buf, "# HELP %s %s\n", f.name, f.help)
+	fmt.Fprintf(buf, "# TYPE %s %s\n", f.name, f.kind)
+
+	for i := range samples {
+		label := `pool="` + escape(samples[i].name) + `"`
+
+		if f.histogram == nil {
+			fmt.Fprintf(buf, "%s{%s} %s\n", f.name, label,
+				number(f.value(&samples[i].stats)),
+			)
+
+			continue
+		}
+
+		h := f.histogram(&samples[i].stats)
+		cumulative := uint64(0)
+
+		for b, count := range h.Counts {
+			cumulative += count
+			le := "+Inf"
+
+			if b < len(h.Bounds) {
+				le = seconds(h.Bounds[b])
+			}
+
+			fmt.Fprintf(buf, "%s_bucket{%s,le=\"%s\"} %d\n", f.name, label, le, cumulative)
+		}
+
+		fmt.Fprintf(buf, "%s_sum{%s} %s\n", f.name, label, seconds(h.Sum))
+		// the count must equal the +Inf bucket, so it is derived from the
+		// same counts, rather than taken from the histogram's Count.
+		fmt.Fprintf(buf, "%s_count{%s} %d\n", f.name, label, cumulative)
+	}
+}
+
+func number(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+func seconds(d time.Duration) string {
+	return number(d.Seconds())
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escape escapes a label value as required by the exposition format.
+func escape(value string) string {
+	return escaper.Replace(value)
+}
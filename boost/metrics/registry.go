@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/snivilised/lorax/boost"
+)
+
+var (
+	// ErrDuplicatePool is returned when registering a pool under a name
+	// that is already in use.
+	ErrDuplicatePool = errors.New("pool already registered")
+
+	// ErrInvalidPoolName is returned when registering a pool without a name.
+	ErrInvalidPoolName = errors.New("pool name must not be empty")
+)
+
+// StatsProvider is implemented by all boost pools.
+type StatsProvider interface {
+	Stats() boost.PoolStats
+}
+
+// Registry holds the named pools whose statistics are exposed as
+// metrics. Pools are identified by the pool label of each metric.
+type Registry struct {
+	mutex sync.RWMutex
+	pools map[string]StatsProvider
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		pools: make(map[string]StatsProvider),
+	}
+}
+
+// Register adds a pool to the registry under the given name.
+func (r *Registry) Register(name string, pool StatsProvider) error {
+	if name == "" {
+		return ErrInvalidPoolName
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, found := r.pools[name]; found {
+		return fmt.Errorf("%w: %q", ErrDuplicatePool, name)
+	}
+
+	r.pools[name] = pool
+
+	return nil
+}
+
+// Unregister removes the named pool from the registry; typically
+// invoked once the pool has been released.
+func (r *Registry) Unregister(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.pools, name)
+}
+
+// sample is the statistics of a single pool at the time of a scrape.
+type sample struct {
+	name  string
+	stats boost.PoolStats
+}
+
+// gather takes a snapshot of all registered pools, ordered by name
+// so that the exposition is stable from one scrape to the next.
+func (r *Registry) gather() []sample {
+	r.mutex.RLock()
+	samples := make([]sample, 0, len(r.pools))
+
+	for name, pool := range r.pools {
+		samples = append(samples, sample{
+			name:  name,
+			stats: pool.Stats(),
+		})
+	}
+	r.mutex.RUnlock()
+
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].name < samples[j].name
+	})
+
+	return samples
+}
@@ -0,0 +1,144 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // ginkgo ok
+	. "github.com/onsi/gomega"    //nolint:revive // gomega ok
+
+	"github.com/snivilised/lorax/boost"
+	"github.com/snivilised/lorax/boost/metrics"
+)
+
+type fixedStats boost.PoolStats
+
+func (s fixedStats) Stats() boost.PoolStats {
+	return boost.PoolStats(s)
+}
+
+var _ = Describe("Registry", func() {
+	var registry *metrics.Registry
+
+	BeforeEach(func() {
+		registry = metrics.NewRegistry()
+	})
+
+	Context("Register", func() {
+		When("name already registered", func() {
+			It("🧪 should: return error", func() {
+				Expect(registry.Register("pool", fixedStats{})).To(Succeed())
+				Expect(registry.Register("pool", fixedStats{})).To(
+					MatchError(metrics.ErrDuplicatePool),
+				)
+			})
+		})
+
+		When("name is empty", func() {
+			It("🧪 should: return error", func() {
+				Expect(registry.Register("", fixedStats{})).To(
+					MatchError(metrics.ErrInvalidPoolName),
+				)
+			})
+		})
+	})
+
+	Context("WriteTo", func() {
+		It("🧪 should: render counters and histograms", func() {
+			Expect(registry.Register(`a"b`, fixedStats{
+				Submitted: 3,
+				Failed:    1,
+				Running:   2,
+				Execution: boost.Histogram{
+					Bounds: []time.Duration{time.Millisecond, time.Second},
+					Counts: []uint64{1, 2, 1},
+					Count:  4,
+					Sum:    time.Millisecond * 1500,
+				},
+			})).To(Succeed())
+
+			var sb strings.Builder
+			_, err := registry.WriteTo(&sb)
+			Expect(err).To(Succeed())
+
+			text := sb.String()
+			Expect(text).To(ContainSubstring("# TYPE lorax_pool_jobs_submitted_total counter\n"))
+			Expect(text).To(ContainSubstring(`lorax_pool_jobs_submitted_total{pool="a\"b"} 3` + "\n"))
+			Expect(text).To(ContainSubstring(`lorax_pool_jobs_failed_total{pool="a\"b"} 1` + "\n"))
+			Expect(text).To(ContainSubstring(`lorax_pool_running_workers{pool="a\"b"} 2` + "\n"))
+			Expect(text).To(ContainSubstring("# TYPE lorax_pool_execution_seconds histogram\n"))
+			Expect(text).To(ContainSubstring(`lorax_pool_execution_seconds_bucket{pool="a\"b",le="0.001"} 1` + "\n"))
+			Expect(text).To(ContainSubstring(`lorax_pool_execution_seconds_bucket{pool="a\"b",le="1"} 3` + "\n"))
+			Expect(text).To(ContainSubstring(`lorax_pool_execution_seconds_bucket{pool="a\"b",le="+Inf"} 4` + "\n"))
+			Expect(text).To(ContainSubstring(`lorax_pool_execution_seconds_sum{pool="a\"b"} 1.5` + "\n"))
+			Expect(text).To(ContainSubstring(`lorax_pool_execution_seconds_count{pool="a\"b"} 4` + "\n"))
+		})
+
+		When("count is inconsistent with buckets", func() {
+			It("🧪 should: render count equal to +Inf bucket", func() {
+				Expect(registry.Register("skewed", fixedStats{
+					Execution: boost.Histogram{
+						Bounds: []time.Duration{time.Millisecond},
+						Counts: []uint64{1, 1},
+						Count:  3,
+					},
+				})).To(Succeed())
+
+				var sb strings.Builder
+				_, err := registry.WriteTo(&sb)
+				Expect(err).To(Succeed())
+
+				text := sb.String()
+				Expect(text).To(ContainSubstring(`lorax_pool_execution_seconds_bucket{pool="skewed",le="+Inf"} 2` + "\n"))
+				Expect(text).To(ContainSubstring(`lorax_pool_execution_seconds_count{pool="skewed"} 2` + "\n"))
+			})
+		})
+	})
+
+	Context("ServeHTTP", func() {
+		It("🧪 should: expose metrics of registered pool", func(specCtx SpecContext) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			pool, err := boost.NewManifoldFuncPool(
+				ctx, func(input int) (int, error) {
+					return input, nil
+				}, &wg,
+				boost.WithSize(2),
+				boost.WithOutput(10, 0, time.Second),
+			)
+			Expect(err).To(Succeed())
+			defer pool.Release(ctx)
+
+			for i := 0; i < 5; i++ {
+				Expect(pool.Post(ctx, i)).To(Succeed())
+			}
+			pool.Conclude(ctx)
+
+			for range pool.Observe() {
+			}
+
+			Expect(registry.Register("squares", pool)).To(Succeed())
+
+			recorder := httptest.NewRecorder()
+			registry.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+			Expect(recorder.Header().Get("Content-Type")).To(Equal(metrics.ContentType))
+			Expect(recorder.Body.String()).To(
+				ContainSubstring(`lorax_pool_jobs_completed_total{pool="squares"} 5` + "\n"),
+			)
+
+			registry.Unregister("squares")
+			recorder = httptest.NewRecorder()
+			registry.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", http.NoBody))
+			Expect(recorder.Body.String()).NotTo(ContainSubstring("squares"))
+		})
+	})
+})
@@ -0,0 +1,57 @@
+package boost
+
+import (
+	"context"
+)
+
+type (
+	// Handler executes a job, returning its output. It is the function
+	// that is wrapped by Middleware.
+	Handler[I, O any] func(ctx context.Context, job Job[I]) JobOutput[O]
+
+	// Middleware intercepts the execution of jobs, for cross-cutting
+	// concerns such as logging, timing and tracing. It returns a handler
+	// that wraps next, which it is expected to invoke, although it is
+	// free to inspect or modify the job beforehand and the output
+	// afterwards, or to skip next altogether, eg to deny the job.
+	Middleware[I, O any] func(next Handler[I, O]) Handler[I, O]
+)
+
+// intercept wraps the handler with the chain of middleware, such that
+// the first middleware is the outermost and so sees the job first and
+// the output last.
+func intercept[I, O any](middleware []Middleware[I, O], handler Handler[I, O]) Handler[I, O] {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+
+	return handler
+}
+
+// intercept wraps the executive function with the chain of middleware,
+// which are invoked with the context provided, since an executive
+// function is not context aware. An error returned by the executive
+// function is reported to the middleware as the Error of the output,
+// unless the output already carries one.
+func (f ExecutiveFunc[I, O]) intercept(ctx context.Context,
+	middleware []Middleware[I, O],
+) ExecutiveFunc[I, O] {
+	if len(middleware) == 0 {
+		return f
+	}
+
+	handler := intercept(middleware, func(_ context.Context, job Job[I]) JobOutput[O] {
+		output, err := f(job)
+		if output.Error == nil {
+			output.Error = err
+		}
+
+		return output
+	})
+
+	return func(job Job[I]) (JobOutput[O], error) {
+		output := handler(ctx, job)
+
+		return output, output.Error
+	}
+}
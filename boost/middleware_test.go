@@ -0,0 +1,313 @@
+package boost_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // ginkgo ok
+	. "github.com/onsi/gomega"    //nolint:revive // gomega ok
+
+	"github.com/snivilised/lorax/boost"
+)
+
+var errDenied = errors.New("denied")
+
+var _ = Describe("Middleware", func() {
+	// trace returns a middleware that records when a job enters and
+	// leaves it.
+	trace := func(mutex *sync.Mutex, events *[]string, name string) boost.Middleware[int, int] {
+		return func(next boost.Handler[int, int]) boost.Handler[int, int] {
+			return func(ctx context.Context, job boost.Job[int]) boost.JobOutput[int] {
+				mutex.Lock()
+				*events = append(*events, name+">")
+				mutex.Unlock()
+
+				output := next(ctx, job)
+
+				mutex.Lock()
+				*events = append(*events, "<"+name)
+				mutex.Unlock()
+
+				return output
+			}
+		}
+	}
+
+	// increment is a middleware that increments the payload of the output.
+	increment := func(next boost.Handler[int, int]) boost.Handler[int, int] {
+		return func(ctx context.Context, job boost.Job[int]) boost.JobOutput[int] {
+			output := next(ctx, job)
+			output.Payload++
+
+			return output
+		}
+	}
+
+	Context("ManifoldFuncPool", func() {
+		When("chain defined", func() {
+			It("🧪 should: wrap job in order", func(specCtx SpecContext) {
+				var (
+					wg     sync.WaitGroup
+					mutex  sync.Mutex
+					events []string
+				)
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				deny := func(next boost.Handler[int, int]) boost.Handler[int, int] {
+					return func(ctx context.Context, job boost.Job[int]) boost.JobOutput[int] {
+						Expect(job.ID).NotTo(BeEmpty())
+
+						if job.Input < 0 {
+							return boost.JobOutput[int]{
+								ID:         job.ID,
+								SequenceNo: job.SequenceNo,
+								Error:      errDenied,
+							}
+						}
+
+						output := next(ctx, job)
+						output.Payload++
+
+						return output
+					}
+				}
+
+				pool, err := boost.NewManifoldFuncPool(
+					ctx, func(input int) (int, error) {
+						mutex.Lock()
+						events = append(events, "job")
+						mutex.Unlock()
+
+						return input * 10, nil
+					}, &wg,
+					boost.WithSize(1),
+					boost.WithOutput(10, 0, TimeoutOnSend),
+					boost.WithMiddleware(trace(&mutex, &events, "a")),
+					boost.WithMiddleware(trace(&mutex, &events, "b"), deny),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				Expect(pool.Post(ctx, 4)).To(Succeed())
+				Expect(pool.Post(ctx, -1)).To(Succeed())
+				pool.Conclude(ctx)
+
+				outputs := map[int]boost.JobOutput[int]{}
+				for output := range pool.Observe() {
+					outputs[output.SequenceNo] = output
+				}
+
+				Expect(outputs[1].Payload).To(Equal(41))
+				Expect(outputs[2].Error).To(MatchError(errDenied))
+				Expect(events).To(Equal([]string{
+					"a>", "b>", "job", "<b", "<a",
+					"a>", "b>", "<b", "<a",
+				}))
+				Expect(pool.Stats().Failed).To(BeEquivalentTo(1))
+			})
+		})
+	})
+
+	Context("TaskPoolE", func() {
+		When("middleware denies task", func() {
+			It("🧪 should: report error", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewTaskPoolE[int](ctx, &wg,
+					boost.WithSize(1),
+					boost.WithMiddleware(func(next boost.Handler[int, any]) boost.Handler[int, any] {
+						return func(ctx context.Context, job boost.Job[int]) boost.JobOutput[any] {
+							if job.Input == 2 {
+								return boost.JobOutput[any]{
+									ID:         job.ID,
+									SequenceNo: job.SequenceNo,
+									Error:      errDenied,
+								}
+							}
+
+							return next(ctx, job)
+						}
+					}),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				for i := 1; i <= 3; i++ {
+					Expect(pool.Post(ctx, boost.TaskE[int]{
+						Input: i,
+						Func: func(int) error {
+							return nil
+						},
+					})).To(Succeed())
+				}
+				pool.Conclude(ctx)
+
+				result := <-pool.Completion()
+				wg.Wait()
+
+				Expect(result.Error).To(MatchError(errDenied))
+				Expect(result.FirstFailure.SequenceNo).To(Equal(2))
+			})
+		})
+	})
+
+	Context("FuncPoolE", func() {
+		When("middleware denies job", func() {
+			It("🧪 should: report error", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewFuncPoolE(ctx, func(int) error {
+					return nil
+				}, &wg,
+					boost.WithSize(1),
+					boost.WithMiddleware(func(next boost.Handler[int, any]) boost.Handler[int, any] {
+						return func(ctx context.Context, job boost.Job[int]) boost.JobOutput[any] {
+							if job.Input == 2 {
+								return boost.JobOutput[any]{
+									ID:         job.ID,
+									SequenceNo: job.SequenceNo,
+									Error:      errDenied,
+								}
+							}
+
+							return next(ctx, job)
+						}
+					}),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				for i := 1; i <= 3; i++ {
+					Expect(pool.Post(ctx, i)).To(Succeed())
+				}
+				pool.Conclude(ctx)
+
+				result := <-pool.Completion()
+				wg.Wait()
+
+				Expect(result.Error).To(MatchError(errDenied))
+				Expect(result.FirstFailure.SequenceNo).To(Equal(2))
+			})
+		})
+	})
+
+	Context("ManifoldTaskPool", func() {
+		When("chain defined", func() {
+			It("🧪 should: wrap every task", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewManifoldTaskPool[int, int](ctx, &wg,
+					boost.WithSize(1),
+					boost.WithOutput(10, 0, TimeoutOnSend),
+					boost.WithMiddleware(increment),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				for i := 1; i <= 3; i++ {
+					Expect(pool.Post(ctx, boost.ManifoldTask[int, int]{
+						Input: i,
+						Func: func(input int) (int, error) {
+							return input * 10, nil
+						},
+					})).To(Succeed())
+				}
+				pool.Conclude(ctx)
+
+				payloads := map[int]int{}
+				for output := range pool.Observe() {
+					payloads[output.SequenceNo] = output.Payload
+				}
+
+				Expect(payloads).To(Equal(map[int]int{1: 11, 2: 21, 3: 31}))
+			})
+		})
+	})
+
+	Context("MultiManifoldFuncPool", func() {
+		When("chain defined", func() {
+			It("🧪 should: wrap every job", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewMultiManifoldFuncPool(
+					ctx, func(input int) (int, error) {
+						return input * 10, nil
+					}, &wg, 2, boost.RoundRobin,
+					boost.WithSize(1),
+					boost.WithOutput(10, 0, TimeoutOnSend),
+					boost.WithMiddleware(increment),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				for i := 1; i <= 3; i++ {
+					Expect(pool.Post(ctx, i)).To(Succeed())
+				}
+				pool.Conclude(ctx)
+
+				payloads := map[int]int{}
+				for output := range pool.Observe() {
+					payloads[output.SequenceNo] = output.Payload
+				}
+
+				Expect(payloads).To(Equal(map[int]int{1: 11, 2: 21, 3: 31}))
+			})
+		})
+	})
+
+	DescribeTable("🧪 should: be rejected by pools whose jobs are opaque or batched",
+		func(specCtx SpecContext, create func(ctx context.Context, wg *sync.WaitGroup) error) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			Expect(create(ctx, &wg)).To(MatchError(boost.ErrOptionUnsupported))
+		},
+		Entry("FuncPool", func(ctx context.Context, wg *sync.WaitGroup) error {
+			_, err := boost.NewFuncPool[int, int](ctx, demoPoolFunc, wg,
+				boost.WithMiddleware(increment),
+			)
+
+			return err
+		}),
+		Entry("TaskPool", func(ctx context.Context, wg *sync.WaitGroup) error {
+			_, err := boost.NewTaskPool[int, int](ctx, wg,
+				boost.WithMiddleware(increment),
+			)
+
+			return err
+		}),
+		Entry("MultiTaskPool", func(ctx context.Context, wg *sync.WaitGroup) error {
+			_, err := boost.NewMultiTaskPool[int, int](ctx, wg, 2, boost.RoundRobin,
+				boost.WithMiddleware(increment),
+			)
+
+			return err
+		}),
+		Entry("ManifoldBatchFuncPool", func(ctx context.Context, wg *sync.WaitGroup) error {
+			_, err := boost.NewManifoldBatchFuncPool(ctx, func(inputs []int) ([]int, error) {
+				return inputs, nil
+			}, wg,
+				boost.WithMiddleware(increment),
+			)
+
+			return err
+		}),
+	)
+})
@@ -1 +1,202 @@
 package boost
+
+import (
+	"errors"
+	"fmt"
+)
+
+type (
+	// KeyFunc derives a key from the input of a job.
+	KeyFunc[I any] func(input I) string
+
+	// restriction rejects an option that is not honoured by the pool
+	// being constructed.
+	restriction func(o *Options) error
+)
+
+// WithKeyFunc sets up the function used to derive a key from the input
+// of each job. When used in conjunction with the KeyHash load-balancing
+// strategy, jobs with the same key are always dispatched to the same pool.
+// When a per-key rate limit is defined, each key is throttled separately.
+func WithKeyFunc[I any](fn KeyFunc[I]) Option {
+	return func(opts *Options) {
+		opts.KeyFunc = fn
+	}
+}
+
+// keyOf derives the key of the input, if a key function has been defined.
+func keyOf[I any](fn KeyFunc[I], input I) string {
+	if fn == nil {
+		return ""
+	}
+
+	return fn(input)
+}
+
+// keyFuncFrom retrieves the typed key function from the options, if
+// one has been defined.
+func keyFuncFrom[I any](o *Options) KeyFunc[I] {
+	if fn, ok := o.KeyFunc.(KeyFunc[I]); ok {
+		return fn
+	}
+
+	return nil
+}
+
+// WithJournal sets up the journal in which the jobs posted to the pool,
+// and their completions, are recorded.
+func WithJournal[I any](journal *Journal[I]) Option {
+	return func(opts *Options) {
+		opts.Journal = journal
+	}
+}
+
+// journalFrom retrieves the typed journal from the options, if one has
+// been defined.
+func journalFrom[I any](o *Options) *Journal[I] {
+	if journal, ok := o.Journal.(*Journal[I]); ok {
+		return journal
+	}
+
+	return nil
+}
+
+// WithCache enables a result cache, in which the payloads of jobs that
+// completed successfully are stored, keyed by fn. A job whose key is
+// found in the cache is not executed; rather its output, bearing its own
+// ID and SequenceNo, is emitted with the cached payload straight away.
+func WithCache[I, O any](cache Cache[O], fn KeyFunc[I]) Option {
+	return func(opts *Options) {
+		opts.Cache = &memo[I, O]{
+			cache:   cache,
+			keyFunc: fn,
+		}
+	}
+}
+
+// memoFrom retrieves the typed result cache from the options, if one
+// has been defined.
+func memoFrom[I, O any](o *Options) *memo[I, O] {
+	if m, ok := o.Cache.(*memo[I, O]); ok && m.cache != nil && m.keyFunc != nil {
+		return m
+	}
+
+	return nil
+}
+
+// WithMiddleware appends to the chain of middleware that intercepts the
+// execution of every job, with the first middleware being the outermost.
+// The type parameters must match those of the pool; for the error
+// returning pools, whose jobs emit only an error, O is any. Pools whose
+// jobs are opaque or executed in batches reject it.
+func WithMiddleware[I, O any](middleware ...Middleware[I, O]) Option {
+	return func(opts *Options) {
+		opts.Middleware = append(middlewareFrom[I, O](opts), middleware...)
+	}
+}
+
+// middlewareFrom retrieves the typed chain of middleware from the
+// options, if one has been defined.
+func middlewareFrom[I, O any](o *Options) []Middleware[I, O] {
+	if middleware, ok := o.Middleware.([]Middleware[I, O]); ok {
+		return middleware
+	}
+
+	return nil
+}
+
+// WithDeduplication enables the coalescing of concurrent jobs with the
+// same key, as derived by fn, so that the manifold function is invoked
+// only once on their behalf. Every job still receives an output, with
+// its own ID and SequenceNo, but with the payload and error shared.
+func WithDeduplication[I any](fn KeyFunc[I]) Option {
+	return func(opts *Options) {
+		opts.Deduplicate = fn
+	}
+}
+
+// checkOptions ensures that the typed options, which have to be stored
+// as an interface{} since Options is not generic, match the type
+// parameters of the pool, returning ErrOptionType for each that does not.
+// The restrictions reject the options that the pool does not honour.
+func checkOptions[I, O any](o *Options, restrictions ...restriction) error {
+	errs := []error{
+		conform[KeyFunc[I]]("WithKeyFunc", o.KeyFunc),
+		conform[*Journal[I]]("WithJournal", o.Journal),
+		conform[KeyFunc[I]]("WithDeduplication", o.Deduplicate),
+		conform[*memo[I, O]]("WithCache", o.Cache),
+		conform[[]Middleware[I, O]]("WithMiddleware", o.Middleware),
+	}
+
+	for _, restrict := range restrictions {
+		errs = append(errs, restrict(o))
+	}
+
+	return errors.Join(errs...)
+}
+
+// withoutCapacity rejects WithCapacity, for the pools whose jobs can not
+// be posted with a Weight.
+func withoutCapacity(o *Options) error {
+	return unsupported("WithCapacity", o.Capacity != 0)
+}
+
+// withoutCache rejects WithCache, for the pools that do not look up the
+// result cache before dispatching a job.
+func withoutCache(o *Options) error {
+	return unsupported("WithCache", o.Cache != nil)
+}
+
+// withoutJournal rejects WithJournal, for the pools that do not record
+// their jobs, which would otherwise be lost on a crash.
+func withoutJournal(o *Options) error {
+	return unsupported("WithJournal", o.Journal != nil)
+}
+
+// withoutPriority rejects WithPriority, for the pools that do not
+// schedule their jobs.
+func withoutPriority(o *Options) error {
+	return unsupported("WithPriority", o.Priority != nil)
+}
+
+// withoutDeduplication rejects WithDeduplication, for the pools that do
+// not execute jobs via a shared function, keyed solely by input; two tasks
+// with the same input but different functions must not be coalesced.
+func withoutDeduplication(o *Options) error {
+	return unsupported("WithDeduplication", o.Deduplicate != nil)
+}
+
+// withoutMiddleware rejects WithMiddleware, for the pools that do not
+// execute jobs individually with a typed input, so could not present the
+// middleware with the full job.
+func withoutMiddleware(o *Options) error {
+	return unsupported("WithMiddleware", o.Middleware != nil)
+}
+
+// unsupported returns ErrOptionUnsupported if the named option has been
+// defined.
+func unsupported(name string, defined bool) error {
+	if !defined {
+		return nil
+	}
+
+	return fmt.Errorf("%w (option: '%v')", ErrOptionUnsupported, name)
+}
+
+// conform returns ErrOptionType if the value of the named option has been
+// defined, but is not of type T.
+func conform[T any](name string, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	if _, ok := value.(T); ok {
+		return nil
+	}
+
+	var want T
+
+	return fmt.Errorf("%w (option: '%v', got: '%T', want: '%T')",
+		ErrOptionType, name, value, want,
+	)
+}
@@ -0,0 +1,350 @@
+package boost
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// stageTimeoutOnSend is the default timeout on send of the pools inside
+// a pipeline. Since a stage is drained by the stage that follows it, a
+// send that takes a long time is just back pressure, which must not
+// result in the loss of an output.
+const stageTimeoutOnSend = time.Duration(math.MaxInt64)
+
+type (
+	// Stage defines a step of a Pipeline, which transforms inputs of
+	// type I into outputs of type O, using its own worker pool, as defined
+	// by its options.
+	Stage[I, O any] struct {
+		fn      ManifoldFuncCtx[I, O]
+		options []Option
+	}
+
+	// parcel is the item passed between the stages of a pipeline. A parcel
+	// carrying an error, from a stage that failed, bypasses the functions
+	// of subsequent stages.
+	parcel[T any] struct {
+		value T
+		err   error
+	}
+
+	// stranded is a job that could not be relayed to the next stage of a
+	// pipeline, eg because that stage is non-blocking and saturated.
+	stranded struct {
+		id  string
+		err error
+	}
+
+	// entrance is the first stage of a pipeline, which is shared by all
+	// the pipelines derived from it. Jobs that could not be relayed
+	// between stages are reported via the stranded channel, so that they
+	// still result in an output.
+	entrance[I any] struct {
+		post      func(ctx context.Context, job Job[parcel[I]]) error
+		conclude  func(ctx context.Context)
+		options   *Options
+		sequence  int32
+		origins   sync.Map
+		once      sync.Once
+		concluded chan struct{}
+		stranded  chan stranded
+	}
+
+	// Pipeline links the worker pools of a chain of stages, such that the
+	// outputs of a stage become the inputs of the next. Jobs are posted to
+	// the first stage and their outputs observed from the last, bearing
+	// the ID and SequenceNo assigned when they entered the pipeline. A job
+	// that fails at any stage, or can not be posted to it, results in an
+	// output whose Error is a StageError, identifying that stage. A
+	// Pipeline is created with NewPipeline and extended with Then.
+	Pipeline[I, O any] struct {
+		ctx      context.Context
+		cancel   context.CancelFunc
+		wg       WaitGroup
+		entrance *entrance[I]
+		stages   int
+		tail     JobOutputStreamR[parcel[O]]
+		releases []func(ctx context.Context)
+		once     sync.Once
+		outputCh chan JobOutput[O]
+	}
+)
+
+// NewStage creates a stage of a pipeline, whose function is executed
+// by a worker pool created with the options provided.
+func NewStage[I, O any](fn ManifoldFunc[I, O], options ...Option) Stage[I, O] {
+	return NewStageCtx(withContext(fn), options...)
+}
+
+// NewStageCtx creates a stage of a pipeline, whose function is context
+// aware.
+func NewStageCtx[I, O any](fn ManifoldFuncCtx[I, O], options ...Option) Stage[I, O] {
+	return Stage[I, O]{
+		fn:      fn,
+		options: options,
+	}
+}
+
+// pool creates the worker pool of the stage.
+func (s Stage[I, O]) pool(ctx context.Context,
+	wg WaitGroup,
+) (*ManifoldFuncPool[parcel[I], parcel[O]], error) {
+	return NewManifoldFuncPoolCtx(ctx,
+		func(ctx context.Context, input parcel[I]) (parcel[O], error) {
+			if input.err != nil {
+				return parcel[O]{err: input.err}, nil
+			}
+
+			value, err := s.fn(ctx, input.value)
+
+			return parcel[O]{value: value}, err
+		}, wg,
+		append([]Option{
+			WithOutput(DefaultChSize, 0, stageTimeoutOnSend),
+		}, s.options...)...,
+	)
+}
+
+// NewPipeline creates a pipeline consisting of a single stage. The
+// pipeline can be cancelled independently of the context provided, by
+// invoking Cancel, which cancels all of its stages.
+func NewPipeline[I, O any](ctx context.Context,
+	wg WaitGroup,
+	stage Stage[I, O],
+) (*Pipeline[I, O], error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	pool, err := stage.pool(ctx, wg)
+	if err != nil {
+		cancel()
+
+		return nil, err
+	}
+
+	p := &Pipeline[I, O]{
+		ctx:    ctx,
+		cancel: cancel,
+		wg:     wg,
+		entrance: &entrance[I]{
+			post:      pool.post,
+			conclude:  pool.Conclude,
+			options:   pool.GetOptions(),
+			concluded: make(chan struct{}),
+			stranded:  make(chan stranded),
+		},
+		stages:   1,
+		tail:     pool.Observe(),
+		releases: []func(ctx context.Context){pool.Release},
+	}
+
+	// cancellation of the pipeline concludes the first stage, which in
+	// turn concludes the rest.
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		select {
+		case <-ctx.Done():
+			p.Conclude(ctx)
+		case <-p.entrance.concluded:
+		}
+	}()
+
+	return p, nil
+}
+
+// Then extends the pipeline with another stage, which receives the
+// outputs of the last stage of the pipeline as its inputs. The pipeline
+// provided must not be used after it has been extended; rather the
+// pipeline returned should be used in its place.
+func Then[I, M, O any](p *Pipeline[I, M],
+	stage Stage[M, O],
+) (*Pipeline[I, O], error) {
+	pool, err := stage.pool(p.ctx, p.wg)
+	if err != nil {
+		return nil, err
+	}
+
+	next := &Pipeline[I, O]{
+		ctx:      p.ctx,
+		cancel:   p.cancel,
+		wg:       p.wg,
+		entrance: p.entrance,
+		stages:   p.stages + 1,
+		tail:     pool.Observe(),
+		releases: append(p.releases, pool.Release),
+	}
+
+	p.wg.Add(1)
+	go func(ctx context.Context, number int) {
+		defer p.wg.Done()
+		defer pool.Conclude(ctx)
+
+		for output := range p.tail {
+			item := output.Payload
+
+			if output.Error != nil {
+				item = parcel[M]{err: &StageError{
+					Stage: number,
+					Err:   output.Error,
+				}}
+			}
+
+			err := pool.post(ctx, Job[parcel[M]]{
+				ID:    output.ID,
+				Input: item,
+			})
+			if err == nil {
+				continue
+			}
+
+			select {
+			case p.entrance.stranded <- stranded{
+				id: output.ID,
+				err: &StageError{
+					Stage: number + 1,
+					Err:   err,
+				},
+			}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}(p.ctx, p.stages)
+
+	return next, nil
+}
+
+// Post submits the input to the first stage of the pipeline.
+func (p *Pipeline[I, O]) Post(ctx context.Context, input I) error {
+	id := p.entrance.options.Generator.Generate()
+	p.entrance.origins.Store(id, int(atomic.AddInt32(&p.entrance.sequence, 1)))
+
+	err := p.entrance.post(ctx, Job[parcel[I]]{
+		ID: id,
+		Input: parcel[I]{
+			value: input,
+		},
+	})
+	if err != nil {
+		p.entrance.origins.Delete(id)
+	}
+
+	return err
+}
+
+// Source returns an input stream through which the client can submit
+// jobs to the pipeline. Closing the stream concludes the pipeline.
+func (p *Pipeline[I, O]) Source(ctx context.Context,
+	wg WaitGroup,
+) SourceStreamW[I] {
+	inputDupCh := source(ctx, wg, p.entrance.options,
+		injector[I](func(input I) error {
+			return p.Post(ctx, input)
+		}),
+		terminator(func() {
+			p.Conclude(ctx)
+		}),
+	)
+
+	return inputDupCh.WriterCh
+}
+
+// Conclude signifies to the pipeline that no more work will be
+// submitted. Each stage is concluded once the stage before it has
+// completed, so the output stream is closed once the last job has
+// passed through all stages.
+func (p *Pipeline[I, O]) Conclude(ctx context.Context) {
+	p.entrance.once.Do(func() {
+		p.entrance.conclude(ctx)
+		close(p.entrance.concluded)
+	})
+}
+
+// Observe returns the output stream of the pipeline, which must only be
+// invoked on the pipeline returned by the final invocation of Then.
+func (p *Pipeline[I, O]) Observe() JobOutputStreamR[O] {
+	p.once.Do(func() {
+		p.outputCh = make(chan JobOutput[O], DefaultChSize)
+
+		p.wg.Add(1)
+		go p.deliver(p.ctx)
+	})
+
+	return p.outputCh
+}
+
+// deliver converts the outputs of the last stage, along with the jobs
+// stranded between stages, into the outputs of the pipeline. A job can
+// only be stranded before the stage that follows has been concluded, so
+// none remain once the last stage has closed its output stream.
+func (p *Pipeline[I, O]) deliver(ctx context.Context) {
+	defer p.wg.Done()
+	defer close(p.outputCh)
+
+	for {
+		var result JobOutput[O]
+
+		select {
+		case output, ok := <-p.tail:
+			if !ok {
+				return
+			}
+
+			result = JobOutput[O]{
+				ID:       output.ID,
+				Payload:  output.Payload.value,
+				Error:    output.Payload.err,
+				Attempts: output.Attempts,
+			}
+
+			if output.Error != nil {
+				result.Error = &StageError{
+					Stage: p.stages,
+					Err:   output.Error,
+				}
+			}
+
+		case job := <-p.entrance.stranded:
+			result = JobOutput[O]{
+				ID:    job.id,
+				Error: job.err,
+			}
+
+		case <-ctx.Done():
+			return
+		}
+
+		if sequenceNo, found := p.entrance.origins.LoadAndDelete(result.ID); found {
+			result.SequenceNo, _ = sequenceNo.(int)
+		}
+
+		select {
+		case p.outputCh <- result:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Cancel cancels the context of all the stages of the pipeline.
+func (p *Pipeline[I, O]) Cancel() {
+	p.cancel()
+}
+
+// Release releases the worker pools of all the stages of the pipeline.
+func (p *Pipeline[I, O]) Release(ctx context.Context) {
+	for _, release := range p.releases {
+		release(ctx)
+	}
+
+	p.cancel()
+}
+
+// Stages returns the number of stages in the pipeline.
+func (p *Pipeline[I, O]) Stages() int {
+	return p.stages
+}
@@ -0,0 +1,197 @@
+package boost_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // ginkgo ok
+	. "github.com/onsi/gomega"    //nolint:revive // gomega ok
+
+	"github.com/snivilised/lorax/boost"
+	"github.com/snivilised/lorax/internal/ants"
+)
+
+var errStage = errors.New("stage failed")
+
+var _ = Describe("Pipeline", func() {
+	var (
+		doubler = boost.NewStage(func(input int) (int, error) {
+			return input * 2, nil
+		}, boost.WithSize(2))
+
+		formatter = boost.NewStage(func(input int) (string, error) {
+			if input == 6 {
+				return "", errStage
+			}
+
+			return fmt.Sprintf("#%v", input), nil
+		}, boost.WithSize(3))
+
+		exclaimer = boost.NewStage(func(input string) (string, error) {
+			return input + "!", nil
+		})
+	)
+
+	build := func(ctx context.Context, wg *sync.WaitGroup) *boost.Pipeline[int, string] {
+		first, err := boost.NewPipeline(ctx, wg, doubler)
+		Expect(err).To(Succeed())
+
+		second, err := boost.Then(first, formatter)
+		Expect(err).To(Succeed())
+
+		third, err := boost.Then(second, exclaimer)
+		Expect(err).To(Succeed())
+		Expect(third.Stages()).To(Equal(3))
+
+		return third
+	}
+
+	collect := func(pipeline *boost.Pipeline[int, string]) map[int]boost.JobOutput[string] {
+		outputs := map[int]boost.JobOutput[string]{}
+		for output := range pipeline.Observe() {
+			outputs[output.SequenceNo] = output
+		}
+
+		return outputs
+	}
+
+	When("jobs are posted", func() {
+		It("🧪 should: pass outputs through all stages", func(specCtx SpecContext) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			pipeline := build(ctx, &wg)
+			defer pipeline.Release(ctx)
+
+			for i := 1; i <= 5; i++ {
+				Expect(pipeline.Post(ctx, i)).To(Succeed())
+			}
+			pipeline.Conclude(ctx)
+
+			outputs := collect(pipeline)
+			Expect(outputs).To(HaveLen(5))
+
+			for seq, output := range outputs {
+				Expect(output.ID).NotTo(BeEmpty())
+
+				if seq == 3 {
+					var stageErr *boost.StageError
+					Expect(errors.As(output.Error, &stageErr)).To(BeTrue())
+					Expect(stageErr.Stage).To(Equal(2))
+					Expect(output.Error).To(MatchError(errStage))
+
+					continue
+				}
+
+				Expect(output.Error).To(Succeed())
+				Expect(output.Payload).To(Equal(fmt.Sprintf("#%v!", seq*2)))
+			}
+			wg.Wait()
+		})
+	})
+
+	When("inputs are sent via source", func() {
+		It("🧪 should: conclude when source is closed", func(specCtx SpecContext) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			pipeline := build(ctx, &wg)
+			defer pipeline.Release(ctx)
+
+			source := pipeline.Source(ctx, &wg)
+			for i := 1; i <= 2; i++ {
+				source <- i
+			}
+			close(source)
+
+			outputs := collect(pipeline)
+			Expect(outputs).To(HaveLen(2))
+			Expect(outputs[2].Payload).To(Equal("#4!"))
+			wg.Wait()
+		})
+	})
+
+	When("cancelled", func() {
+		It("🧪 should: close output stream", func(specCtx SpecContext) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			blocker := boost.NewStageCtx(func(ctx context.Context, input int) (int, error) {
+				<-ctx.Done()
+
+				return input, ctx.Err()
+			})
+
+			first, err := boost.NewPipeline(ctx, &wg, blocker)
+			Expect(err).To(Succeed())
+
+			pipeline, err := boost.Then(first, formatter)
+			Expect(err).To(Succeed())
+			defer pipeline.Release(ctx)
+
+			Expect(pipeline.Post(ctx, 1)).To(Succeed())
+			outputs := pipeline.Observe()
+
+			pipeline.Cancel()
+			Eventually(outputs).Should(BeClosed())
+			wg.Wait()
+		})
+	})
+
+	When("stage is saturated", func() {
+		It("🧪 should: report jobs it could not take", func(specCtx SpecContext) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			const jobs = 10
+
+			first, err := boost.NewPipeline(ctx, &wg, doubler)
+			Expect(err).To(Succeed())
+
+			second, err := boost.Then(first, boost.NewStage(func(input int) (int, error) {
+				time.Sleep(time.Millisecond * 20)
+
+				return input, nil
+			}, boost.WithSize(1), boost.WithNonblocking(true)))
+			Expect(err).To(Succeed())
+			defer second.Release(ctx)
+
+			for i := 1; i <= jobs; i++ {
+				Expect(second.Post(ctx, i)).To(Succeed())
+			}
+			second.Conclude(ctx)
+
+			var (
+				overloaded int
+				sequence   = map[int]bool{}
+			)
+
+			for output := range second.Observe() {
+				sequence[output.SequenceNo] = true
+
+				if output.Error != nil {
+					var se *boost.StageError
+					Expect(errors.As(output.Error, &se)).To(BeTrue())
+					Expect(se.Stage).To(Equal(2))
+					Expect(output.Error).To(MatchError(ants.ErrPoolOverload))
+					overloaded++
+				}
+			}
+
+			Expect(sequence).To(HaveLen(jobs))
+			Expect(overloaded).To(BeNumerically(">", 0))
+			wg.Wait()
+		})
+	})
+})
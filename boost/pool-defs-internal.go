@@ -58,6 +58,7 @@ type outputInfoW[O any] struct {
 	outputCh      JobOutputStreamW[O]
 	cancelCh      CancelStreamW
 	timeoutOnSend time.Duration
+	stats         *collector
 }
 
 // Worker pool types:
@@ -0,0 +1,264 @@
+package boost
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// bucket is a token bucket, which holds up to burst tokens and is
+// refilled continuously at rate tokens per second.
+type bucket struct {
+	mutex  sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	// holds is the number of jobs acquiring a token from the bucket of a
+	// key, whilst which the bucket must not be evicted. It is guarded by
+	// the mutex of the limiter.
+	holds int
+}
+
+func newBucket(rate float64, burst int) *bucket {
+	capacity := float64(max(burst, 1))
+
+	return &bucket{
+		rate:   rate,
+		burst:  capacity,
+		tokens: capacity,
+		last:   time.Now(),
+	}
+}
+
+// advance refills the bucket with the tokens accrued since it was last
+// updated; the mutex must be held.
+func (b *bucket) advance(now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed.Seconds()*b.rate)
+		b.last = now
+	}
+}
+
+// until returns how long it takes for the deficit to be refilled.
+func (b *bucket) until(deficit float64) time.Duration {
+	return time.Duration(deficit / b.rate * float64(time.Second))
+}
+
+// reserve takes a token, even if it has not yet accrued, returning how
+// long the caller must wait before the token can be used.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.advance(now)
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return b.until(-b.tokens)
+}
+
+// take takes a token only if one is available now. Otherwise, it
+// returns how long it will be until one is.
+func (b *bucket) take(now time.Time) (time.Duration, bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.advance(now)
+
+	if b.tokens >= 1 {
+		b.tokens--
+
+		return 0, true
+	}
+
+	return b.until(1 - b.tokens), false
+}
+
+// full indicates whether the bucket has been refilled to its burst, in
+// which case it is indistinguishable from a new bucket.
+func (b *bucket) full(now time.Time) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.advance(now)
+
+	return b.tokens >= b.burst
+}
+
+// restore returns a token that was taken but not used.
+func (b *bucket) restore() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.tokens = min(b.burst, b.tokens+1)
+}
+
+// limiter throttles the dispatching of jobs according to the rate limit
+// option; a job must acquire a token from the shared bucket, if defined,
+// and also from the bucket of its key, if a per-key limit is defined.
+// Since the number of distinct keys is unbounded, the buckets of keys
+// that are idle are evicted, whenever the number of buckets has doubled
+// since the last sweep.
+type limiter struct {
+	limit  RateLimit
+	shared *bucket
+	mutex  sync.Mutex
+	keyed  map[string]*bucket
+	sweep  int
+}
+
+func newLimiter(o *Options) *limiter {
+	if o.RateLimit == nil {
+		return nil
+	}
+
+	l := &limiter{
+		limit: *o.RateLimit,
+		keyed: make(map[string]*bucket),
+		sweep: minSweep,
+	}
+
+	if l.limit.Rate > 0 {
+		l.shared = newBucket(l.limit.Rate, l.limit.Burst)
+	}
+
+	return l
+}
+
+// isKeyed indicates whether the job with the key is subject to the
+// per-key limit.
+func (l *limiter) isKeyed(key string) bool {
+	return l.limit.KeyRate > 0 && key != ""
+}
+
+// buckets returns the buckets from which a job with the key must
+// acquire a token. The bucket of the key is held, so that it can not be
+// evicted, until it is released.
+func (l *limiter) buckets(key string) []*bucket {
+	buckets := make([]*bucket, 0, 2)
+
+	if l.isKeyed(key) {
+		l.mutex.Lock()
+		b, found := l.keyed[key]
+
+		if !found {
+			l.evict()
+
+			b = newBucket(l.limit.KeyRate, l.limit.KeyBurst)
+			l.keyed[key] = b
+		}
+
+		b.holds++
+		l.mutex.Unlock()
+
+		buckets = append(buckets, b)
+	}
+
+	if l.shared != nil {
+		buckets = append(buckets, l.shared)
+	}
+
+	return buckets
+}
+
+// release releases the bucket of the key, held by buckets.
+func (l *limiter) release(key string) {
+	if !l.isKeyed(key) {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.keyed[key].holds--
+}
+
+// evict removes the buckets of keys that are neither held nor depleted,
+// once the number of buckets has reached the sweep threshold. The mutex
+// must be held.
+func (l *limiter) evict() {
+	if len(l.keyed) < l.sweep {
+		return
+	}
+
+	now := time.Now()
+
+	for key, b := range l.keyed {
+		if b.holds == 0 && b.full(now) {
+			delete(l.keyed, key)
+		}
+	}
+
+	l.sweep = max(len(l.keyed)*2, minSweep)
+}
+
+// keys returns the number of keys whose buckets are held by the limiter.
+func (l *limiter) keys() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return len(l.keyed)
+}
+
+// wait blocks until the job with the key is allowed to be dispatched, or
+// the context is cancelled. In non-blocking mode, a RateLimitError is
+// returned instead of waiting.
+func (l *limiter) wait(ctx context.Context, key string) error {
+	buckets := l.buckets(key)
+	defer l.release(key)
+
+	if l.limit.NonBlocking {
+		return l.try(key, buckets)
+	}
+
+	var (
+		now   = time.Now()
+		delay time.Duration
+	)
+
+	for _, b := range buckets {
+		delay = max(delay, b.reserve(now))
+	}
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		for _, b := range buckets {
+			b.restore()
+		}
+
+		return ctx.Err()
+	}
+}
+
+func (l *limiter) try(key string, buckets []*bucket) error {
+	now := time.Now()
+
+	for i, b := range buckets {
+		if after, ok := b.take(now); !ok {
+			for _, taken := range buckets[:i] {
+				taken.restore()
+			}
+
+			return &RateLimitError{
+				Key:        key,
+				RetryAfter: after,
+			}
+		}
+	}
+
+	return nil
+}
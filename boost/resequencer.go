@@ -0,0 +1,120 @@
+package boost
+
+import (
+	"context"
+	"errors"
+
+	"github.com/emirpasic/gods/trees/binaryheap"
+)
+
+// errSkipped marks the sequence number of a job that will never produce
+// an output, so the resequencer must not wait for it.
+var errSkipped = errors.New("skipped")
+
+// resequencer restores the order of outputs, which otherwise arrive in
+// order of completion, so that they are released strictly by SequenceNo.
+// Out of order outputs are buffered in a min-heap. The size of the buffer
+// is bounded by the number of slots; a slot is acquired before a job is
+// posted and only released once its output has been released, so the
+// number of buffered outputs can never exceed the bound.
+type resequencer[O any] struct {
+	inputCh chan JobOutput[O]
+	slots   chan struct{}
+	wi      *outputInfoW[O]
+}
+
+func newResequencer[O any](bound uint, wi *outputInfoW[O]) *resequencer[O] {
+	return &resequencer[O]{
+		inputCh: make(chan JobOutput[O], bound),
+		slots:   make(chan struct{}, bound),
+		wi:      wi,
+	}
+}
+
+// writer returns the output info through which workers send their
+// outputs to the resequencer.
+func (r *resequencer[O]) writer() *outputInfoW[O] {
+	return &outputInfoW[O]{
+		outputCh:      r.inputCh,
+		cancelCh:      r.wi.cancelCh,
+		timeoutOnSend: r.wi.timeoutOnSend,
+		stats:         r.wi.stats,
+	}
+}
+
+// acquire blocks until the job about to be posted can be accommodated
+// by the reorder buffer.
+func (r *resequencer[O]) acquire(ctx context.Context) error {
+	select {
+	case r.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// tryAcquire acquires a slot only if one is available now.
+func (r *resequencer[O]) tryAcquire() bool {
+	select {
+	case r.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// skip informs the resequencer that the job with this sequence number
+// will never produce an output.
+func (r *resequencer[O]) skip(sequenceNo int) {
+	r.inputCh <- JobOutput[O]{
+		SequenceNo: sequenceNo,
+		Error:      errSkipped,
+	}
+}
+
+// close indicates there are no more outputs to come.
+func (r *resequencer[O]) close() {
+	close(r.inputCh)
+}
+
+// run starts the go routine that releases outputs in order to the
+// client's output stream, which is closed once all outputs have been
+// released.
+func (r *resequencer[O]) run(ctx context.Context) {
+	go func(ctx context.Context) {
+		next := 1
+		minHeap := binaryheap.NewWith(func(a, b any) int {
+			oa, _ := a.(JobOutput[O])
+			ob, _ := b.(JobOutput[O])
+
+			return oa.SequenceNo - ob.SequenceNo
+		})
+
+		release := func(flush bool) {
+			for !minHeap.Empty() {
+				v, _ := minHeap.Peek()
+				output, _ := v.(JobOutput[O])
+
+				if output.SequenceNo != next && !flush {
+					return
+				}
+
+				minHeap.Pop()
+				next = output.SequenceNo + 1
+
+				if !errors.Is(output.Error, errSkipped) {
+					_ = respond(ctx, r.wi, &output)
+				}
+				<-r.slots
+			}
+		}
+
+		for output := range r.inputCh {
+			minHeap.Push(output)
+			release(false)
+		}
+
+		release(true)
+		close(r.wi.outputCh)
+	}(ctx)
+}
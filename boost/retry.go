@@ -0,0 +1,65 @@
+package boost
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// retrier applies the retry policy to the execution of a job.
+type retrier struct {
+	policy *RetryPolicy
+}
+
+func newRetrier(o *Options) *retrier {
+	return &retrier{
+		policy: o.Retry,
+	}
+}
+
+// do invokes fn until it succeeds, the retry policy is exhausted, or the
+// context is cancelled. It returns the number of attempts made and the
+// error returned by the final attempt.
+func (r *retrier) do(ctx context.Context, fn func() error) (attempts int, err error) {
+	var b backoff.BackOff
+
+	for {
+		attempts++
+
+		if err = fn(); err == nil || !r.retryable(attempts, err) {
+			return attempts, err
+		}
+
+		if b == nil {
+			b = r.backOff()
+		}
+
+		wait := b.NextBackOff()
+		if wait == backoff.Stop {
+			return attempts, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempts, err
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (r *retrier) retryable(attempts int, err error) bool {
+	if r.policy == nil || attempts >= int(r.policy.MaxAttempts) {
+		return false
+	}
+
+	return r.policy.ShouldRetry == nil || r.policy.ShouldRetry(err)
+}
+
+func (r *retrier) backOff() backoff.BackOff {
+	if r.policy.BackOff == nil {
+		return &backoff.ZeroBackOff{}
+	}
+
+	return r.policy.BackOff()
+}
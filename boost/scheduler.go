@@ -0,0 +1,161 @@
+package boost
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/emirpasic/gods/trees/binaryheap"
+)
+
+type (
+	// scheduled is a job waiting in the scheduler's queue. The score is
+	// fixed at the time the job is queued, which is possible because
+	// all waiting jobs age at the same rate, so their relative order
+	// never changes with time.
+	scheduled[I any] struct {
+		job   Job[I]
+		score int64
+	}
+
+	// scheduler sits in front of the ants pool, queueing posted jobs and
+	// dispatching them in order of priority. Aging raises the effective
+	// priority of a job by 1 for every aging period it has been waiting,
+	// which prevents low priority jobs from being starved.
+	scheduler[I any] struct {
+		mutex  sync.Mutex
+		queue  *binaryheap.Heap
+		signal chan struct{}
+		closed bool
+		aging  time.Duration
+		start  time.Time
+	}
+)
+
+func newScheduler[I any](aging time.Duration) *scheduler[I] {
+	return &scheduler[I]{
+		queue: binaryheap.NewWith(func(a, b any) int {
+			sa, _ := a.(*scheduled[I])
+			sb, _ := b.(*scheduled[I])
+
+			if sa.score != sb.score {
+				if sa.score > sb.score {
+					return -1
+				}
+
+				return 1
+			}
+
+			return sa.job.SequenceNo - sb.job.SequenceNo
+		}),
+		signal: make(chan struct{}, 1),
+		aging:  aging,
+		start:  time.Now(),
+	}
+}
+
+// push queues the job, ready to be dispatched.
+func (s *scheduler[I]) push(job Job[I]) {
+	score := int64(job.Priority)
+
+	if s.aging > 0 {
+		score = score*int64(s.aging) - int64(time.Since(s.start))
+	}
+
+	s.mutex.Lock()
+	s.queue.Push(&scheduled[I]{
+		job:   job,
+		score: score,
+	})
+	s.mutex.Unlock()
+
+	s.notify()
+}
+
+// close indicates that no more jobs will be pushed, so the dispatcher
+// exits once the queue has been emptied.
+func (s *scheduler[I]) close() {
+	s.mutex.Lock()
+	s.closed = true
+	s.mutex.Unlock()
+
+	s.notify()
+}
+
+// drain removes all queued jobs and closes the scheduler, so that the
+// dispatcher exits as soon as it has finished with its current job.
+func (s *scheduler[I]) drain() []Job[I] {
+	s.mutex.Lock()
+	jobs := make([]Job[I], 0, s.queue.Size())
+
+	for !s.queue.Empty() {
+		v, _ := s.queue.Pop()
+		item, _ := v.(*scheduled[I])
+		jobs = append(jobs, item.job)
+	}
+
+	s.closed = true
+	s.mutex.Unlock()
+
+	s.notify()
+
+	return jobs
+}
+
+func (s *scheduler[I]) notify() {
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+func (s *scheduler[I]) pop() (job Job[I], ok, closed bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if v, found := s.queue.Pop(); found {
+		item, _ := v.(*scheduled[I])
+
+		return item.job, true, s.closed
+	}
+
+	return job, false, s.closed
+}
+
+// run launches the dispatcher go routine, which invokes dispatch for
+// each job in order of priority. dispatch is expected to block whilst
+// the pool has no available workers, which is what allows jobs to
+// accumulate in the queue and be re-ordered. When the context is
+// cancelled, all jobs still queued are passed to abandon.
+func (s *scheduler[I]) run(ctx context.Context,
+	wg WaitGroup,
+	dispatch func(job Job[I]),
+	abandon func(job Job[I]),
+) {
+	wg.Add(1)
+	go func(ctx context.Context) {
+		defer wg.Done()
+
+		for {
+			job, ok, closed := s.pop()
+
+			switch {
+			case ok && ctx.Err() == nil:
+				dispatch(job)
+
+			case ok:
+				abandon(job)
+
+			case closed:
+				return
+
+			default:
+				select {
+				case <-ctx.Done():
+					s.close()
+				case <-s.signal:
+				}
+			}
+		}
+	}(ctx)
+}
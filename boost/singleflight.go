@@ -0,0 +1,89 @@
+package boost
+
+import (
+	"context"
+	"sync"
+)
+
+type (
+	// call is an execution of the manifold function, whose output is
+	// shared by all the jobs with the same key that run concurrently.
+	call[O any] struct {
+		done   chan struct{}
+		output JobOutput[O]
+	}
+
+	// flights coalesces concurrent jobs with the same key, so that the
+	// manifold function is only invoked once on their behalf.
+	flights[I, O any] struct {
+		mutex   sync.Mutex
+		keyFunc KeyFunc[I]
+		calls   map[string]*call[O]
+	}
+)
+
+func newFlights[I, O any](o *Options) *flights[I, O] {
+	fn, ok := o.Deduplicate.(KeyFunc[I])
+	if !ok || fn == nil {
+		return nil
+	}
+
+	return &flights[I, O]{
+		keyFunc: fn,
+		calls:   make(map[string]*call[O]),
+	}
+}
+
+// do invokes fn on behalf of the job, unless a job with the same key is
+// already executing, in which case the job waits for and then shares the
+// output of the other, albeit with its own ID and SequenceNo.
+func (f *flights[I, O]) do(ctx context.Context,
+	job *Job[I],
+	fn func() JobOutput[O],
+) JobOutput[O] {
+	key := f.keyFunc(job.Input)
+
+	f.mutex.Lock()
+
+	if c, found := f.calls[key]; found {
+		f.mutex.Unlock()
+
+		select {
+		case <-c.done:
+			output := c.output
+			output.ID, output.SequenceNo = job.ID, job.SequenceNo
+
+			return output
+
+		case <-ctx.Done():
+			return JobOutput[O]{
+				ID:         job.ID,
+				SequenceNo: job.SequenceNo,
+				Error:      ctx.Err(),
+			}
+		}
+	}
+
+	c := &call[O]{
+		done: make(chan struct{}),
+	}
+	f.calls[key] = c
+	f.mutex.Unlock()
+
+	defer func() {
+		f.mutex.Lock()
+		delete(f.calls, key)
+		f.mutex.Unlock()
+
+		close(c.done)
+	}()
+
+	// in case fn panics without being recovered, the waiting jobs must
+	// still be released.
+	c.output = JobOutput[O]{
+		Error: ErrJobPanic,
+	}
+	c.output = fn()
+
+	return c.output
+}
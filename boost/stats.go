@@ -0,0 +1,219 @@
+package boost
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// PoolStats is a snapshot of the statistics of a pool, as returned
+	// by Stats. Submitted jobs are those that have been accepted by the
+	// pool. Of those, Completed jobs are those that executed without error
+	// and Failed jobs are those that resulted in an error, which includes
+	// Panicked and TimedOut jobs. So any shortfall of Completed and Failed
+	// against Submitted represents jobs still in flight.
+	PoolStats struct {
+		Submitted    uint64
+		Completed    uint64
+		Failed       uint64
+		Panicked     uint64
+		TimedOut     uint64
+		SendTimeouts uint64
+
+		// CacheHits and CacheMisses count the jobs whose key was, or was
+		// not, found in the result cache, if one has been defined.
+		CacheHits   uint64
+		CacheMisses uint64
+
+		// QueueWait is the distribution of the time jobs spent waiting
+		// between being posted and starting execution.
+		QueueWait Histogram
+
+		// Execution is the distribution of the time jobs spent executing.
+		Execution Histogram
+
+		// Running and Waiting are as reported by the Running and Waiting
+		// methods of the pool.
+		Running int
+		Waiting int
+
+		// InUseWeight is the total weight of the jobs in flight, which is
+		// only reported by pools that observe the capacity option.
+		InUseWeight int64
+
+		// Spawned is the number of worker go routines started and Purged
+		// is the number of idle workers that have since been cleared.
+		Spawned uint64
+		Purged  uint64
+	}
+
+	// Histogram is a distribution of durations. Counts[i] is the number of
+	// observations less than or equal to Bounds[i] but greater than the
+	// preceding bound. Counts has an additional final element, which is the
+	// number of observations that exceeded the last bound.
+	Histogram struct {
+		Bounds []time.Duration
+		Counts []uint64
+		Count  uint64
+		Sum    time.Duration
+	}
+)
+
+// histogramBounds are the upper bounds of the histogram buckets.
+var histogramBounds = [...]time.Duration{
+	time.Microsecond * 100,
+	time.Millisecond,
+	time.Millisecond * 5,
+	time.Millisecond * 10,
+	time.Millisecond * 50,
+	time.Millisecond * 100,
+	time.Millisecond * 500,
+	time.Second,
+	time.Second * 5,
+	time.Second * 10,
+}
+
+// histogram accumulates observations without locking, so that it can be
+// updated concurrently by workers.
+type histogram struct {
+	counts [len(histogramBounds) + 1]atomic.Uint64
+	sum    atomic.Int64
+}
+
+func (h *histogram) observe(d time.Duration) {
+	i := 0
+	for i < len(histogramBounds) && d > histogramBounds[i] {
+		i++
+	}
+
+	h.counts[i].Add(1)
+	h.sum.Add(int64(d))
+}
+
+func (h *histogram) snapshot() Histogram {
+	result := Histogram{
+		Bounds: histogramBounds[:],
+		Counts: make([]uint64, len(h.counts)),
+		Sum:    time.Duration(h.sum.Load()),
+	}
+
+	// Count is derived from the counts of the snapshot, rather than being
+	// accumulated separately, so that it is consistent with them despite
+	// concurrent observations.
+	for i := range h.counts {
+		result.Counts[i] = h.counts[i].Load()
+		result.Count += result.Counts[i]
+	}
+
+	return result
+}
+
+// collector gathers the statistics of a pool. The zero value is ready
+// to use.
+type collector struct {
+	submitted    atomic.Uint64
+	completed    atomic.Uint64
+	failed       atomic.Uint64
+	panicked     atomic.Uint64
+	timedOut     atomic.Uint64
+	sendTimeouts atomic.Uint64
+	cacheHits    atomic.Uint64
+	cacheMisses  atomic.Uint64
+	queueWait    histogram
+	execution    histogram
+}
+
+// workerStats is implemented by the underlying ants pools.
+type workerStats interface {
+	Running() int
+	Waiting() int
+	Spawned() uint64
+	Purged() uint64
+}
+
+func (c *collector) submit() {
+	c.submitted.Add(1)
+}
+
+// withdraw reverses submit, for a job that could not be submitted.
+func (c *collector) withdraw() {
+	c.submitted.Add(^uint64(0))
+}
+
+func (c *collector) sendTimeout() {
+	c.sendTimeouts.Add(1)
+}
+
+func (c *collector) cacheHit() {
+	c.cacheHits.Add(1)
+}
+
+func (c *collector) cacheMiss() {
+	c.cacheMisses.Add(1)
+}
+
+// measure executes fn, recording the time the job spent in the queue,
+// when it is known, along with the execution time and the outcome. A
+// panic raised by fn is recorded before being propagated.
+func (c *collector) measure(posted time.Time, fn func() error) (err error) {
+	started := time.Now()
+
+	if !posted.IsZero() {
+		c.queueWait.observe(started.Sub(posted))
+	}
+
+	panicking := true
+
+	defer func() {
+		c.execution.observe(time.Since(started))
+
+		if panicking {
+			err = ErrJobPanic
+		}
+
+		c.outcome(err)
+	}()
+
+	err = fn()
+	panicking = false
+
+	return err
+}
+
+// outcome records the result of a job.
+func (c *collector) outcome(err error) {
+	if err == nil {
+		c.completed.Add(1)
+
+		return
+	}
+
+	c.failed.Add(1)
+
+	switch {
+	case errors.Is(err, ErrJobPanic):
+		c.panicked.Add(1)
+	case errors.Is(err, ErrJobTimeout):
+		c.timedOut.Add(1)
+	}
+}
+
+func (c *collector) snapshot(ws workerStats) PoolStats {
+	return PoolStats{
+		Submitted:    c.submitted.Load(),
+		Completed:    c.completed.Load(),
+		Failed:       c.failed.Load(),
+		Panicked:     c.panicked.Load(),
+		TimedOut:     c.timedOut.Load(),
+		SendTimeouts: c.sendTimeouts.Load(),
+		CacheHits:    c.cacheHits.Load(),
+		CacheMisses:  c.cacheMisses.Load(),
+		QueueWait:    c.queueWait.snapshot(),
+		Execution:    c.execution.snapshot(),
+		Running:      ws.Running(),
+		Waiting:      ws.Waiting(),
+		Spawned:      ws.Spawned(),
+		Purged:       ws.Purged(),
+	}
+}
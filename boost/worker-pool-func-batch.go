@@ -0,0 +1,397 @@
+package boost
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/snivilised/lorax/internal/ants"
+)
+
+const (
+	// DefaultBatchSize is the maximum size of a batch, when not defined
+	// by the WithBatch option.
+	DefaultBatchSize = 64
+
+	// DefaultBatchLinger is how long the first job of a batch waits for
+	// the batch to fill up, when not defined by the WithBatch option.
+	DefaultBatchLinger = time.Millisecond * 10
+)
+
+type (
+	// ManifoldBatchFunc is the pre-defined function registered with the
+	// batch based worker pool, executed for each batch of jobs. The
+	// outputs must correspond, one for one, with the inputs.
+	ManifoldBatchFunc[I, O any] func(inputs []I) ([]O, error)
+)
+
+// ManifoldBatchFuncPool is a wrapper around the underlying ants function
+// based worker pool, for work that is cheaper to perform in bulk. Posted
+// jobs are accumulated into batches, each of which is dispatched either
+// once it is full, or once its first job has lingered for long enough.
+// The outputs of a batch are then fanned out, so that the client still
+// receives an output for each job, bearing the job's ID and SequenceNo.
+// If the batch function fails, every job in the batch receives its error.
+type ManifoldBatchFuncPool[I, O any] struct {
+	basePool[I, O]
+	functionalPool
+	wi            *outputInfoW[O]
+	retrier       *retrier
+	recoverPanics bool
+	size          int
+	linger        time.Duration
+	gather        sync.Mutex
+	batch         []Job[I]
+	cut           uint64
+	timer         *time.Timer
+}
+
+// NewManifoldBatchFuncPool creates a new batch based worker pool.
+func NewManifoldBatchFuncPool[I, O any](ctx context.Context,
+	bf ManifoldBatchFunc[I, O],
+	wg WaitGroup,
+	options ...Option,
+) (*ManifoldBatchFuncPool[I, O], error) {
+	o := ants.NewOptions(options...)
+	if err := checkOptions[I, O](o,
+		withoutCapacity, withoutCache, withoutMiddleware, withoutJournal, withoutPriority,
+		withoutDeduplication,
+	); err != nil {
+		return nil, err
+	}
+
+	p := &ManifoldBatchFuncPool[I, O]{
+		basePool: basePool[I, O]{
+			wg:      wg,
+			limiter: newLimiter(o),
+		},
+		retrier:       newRetrier(o),
+		recoverPanics: o.RecoverPanics,
+		size:          DefaultBatchSize,
+		linger:        DefaultBatchLinger,
+	}
+
+	if o.Batch != nil {
+		p.size, p.linger = max(int(o.Batch.MaxSize), 1), o.Batch.Linger
+	}
+
+	p.wi = p.outputs(ctx, o)
+	pool, err := ants.NewPoolWithFunc(ctx, func(input InputParam) {
+		if batch, ok := input.([]Job[I]); ok {
+			p.run(ctx, bf, batch)
+		}
+	}, ants.WithOptions(*o))
+
+	p.functionalPool = functionalPool{
+		pool: pool,
+	}
+	p.workers = pool
+
+	return p, err
+}
+
+// Post allows the client to submit to the work pool represented by
+// input values of type I. The job is added to the current batch, which
+// is dispatched if it is now full, so Post may block according to the
+// blocking options. A batch that could not be dispatched results in an
+// output for each of its jobs, carrying the reason as its error.
+func (p *ManifoldBatchFuncPool[I, O]) Post(ctx context.Context, input I) error {
+	if err := p.accepting(); err != nil {
+		return err
+	}
+
+	if err := p.throttle(ctx, ""); err != nil {
+		return err
+	}
+
+	if err := p.reserve(ctx); err != nil {
+		return err
+	}
+
+	o := p.pool.GetOptions()
+	job := Job[I]{
+		ID:         o.Generator.Generate(),
+		Input:      input,
+		SequenceNo: int(p.next()),
+		posted:     time.Now(),
+	}
+
+	p.hold(job)
+	p.enter()
+	p.stats.submit()
+
+	if batch := p.gatherJob(ctx, job); batch != nil {
+		p.flush(ctx, batch)
+	}
+
+	return nil
+}
+
+// reserve acquires a slot in the reorder buffer, when ordered output is
+// enabled. The outputs of the jobs in the current batch can not be
+// released until the batch has been dispatched, so if the buffer is at
+// its bound, the partial batch is dispatched before waiting for a slot;
+// otherwise the wait could only end when the batch lingers, or never if
+// there is no linger.
+func (p *ManifoldBatchFuncPool[I, O]) reserve(ctx context.Context) error {
+	if p.ordered == nil || p.ordered.tryAcquire() {
+		return nil
+	}
+
+	p.flush(ctx, p.drain())
+
+	return p.admit(ctx)
+}
+
+// gatherJob adds the job to the current batch, returning the batch if it
+// is now full. When the job is the first of a batch, the linger timer is
+// started.
+func (p *ManifoldBatchFuncPool[I, O]) gatherJob(ctx context.Context, job Job[I]) []Job[I] {
+	p.gather.Lock()
+	defer p.gather.Unlock()
+
+	p.batch = append(p.batch, job)
+
+	if len(p.batch) >= p.size {
+		return p.take()
+	}
+
+	if len(p.batch) == 1 && p.linger > 0 {
+		cut := p.cut
+
+		p.timer = time.AfterFunc(p.linger, func() {
+			p.gather.Lock()
+			var batch []Job[I]
+
+			// the timer of a batch that has since been taken is stale
+			if cut == p.cut {
+				batch = p.take()
+			}
+			p.gather.Unlock()
+
+			p.flush(ctx, batch)
+		})
+	}
+
+	return nil
+}
+
+// take removes the current batch, so that subsequent jobs start a new
+// one. The caller must hold the gather lock.
+func (p *ManifoldBatchFuncPool[I, O]) take() []Job[I] {
+	batch := p.batch
+	p.batch = nil
+	p.cut++
+
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+
+	return batch
+}
+
+// drain removes the current batch.
+func (p *ManifoldBatchFuncPool[I, O]) drain() []Job[I] {
+	p.gather.Lock()
+	defer p.gather.Unlock()
+
+	return p.take()
+}
+
+// flush dispatches the batch to the underlying pool.
+func (p *ManifoldBatchFuncPool[I, O]) flush(ctx context.Context, batch []Job[I]) {
+	if len(batch) == 0 {
+		return
+	}
+
+	err := p.pool.Invoke(ctx, batch)
+	if err == nil {
+		return
+	}
+
+	for i := range batch {
+		if p.accepting() != nil {
+			p.fail(batch[i].SequenceNo)
+
+			continue
+		}
+
+		p.started(batch[i].SequenceNo)
+		p.deliver(ctx, &JobOutput[O]{
+			ID:         batch[i].ID,
+			SequenceNo: batch[i].SequenceNo,
+			Error:      err,
+		})
+	}
+}
+
+// run executes the batch function, as many times as allowed by the retry
+// policy, then delivers the output of each job in the batch.
+func (p *ManifoldBatchFuncPool[I, O]) run(ctx context.Context,
+	bf ManifoldBatchFunc[I, O],
+	batch []Job[I],
+) {
+	started := time.Now()
+	inputs := make([]I, len(batch))
+
+	for i := range batch {
+		p.started(batch[i].SequenceNo)
+		p.stats.queueWait.observe(started.Sub(batch[i].posted))
+		inputs[i] = batch[i].Input
+	}
+
+	var (
+		payloads  []O
+		delivered int
+	)
+
+	// jobs whose outputs are not delivered, because the batch function
+	// panicked without being recovered, must still end their lifetime.
+	defer func() {
+		for range batch[delivered:] {
+			p.stats.outcome(ErrJobPanic)
+			p.leave()
+		}
+	}()
+
+	attempts, err := p.retrier.do(ctx, func() (err error) {
+		payloads, err = p.attempt(bf, inputs)
+
+		return err
+	})
+	p.stats.execution.observe(time.Since(started))
+
+	for i := range batch {
+		output := JobOutput[O]{
+			ID:         batch[i].ID,
+			SequenceNo: batch[i].SequenceNo,
+			Error:      identify(err, &batch[i]),
+			Attempts:   attempts,
+		}
+
+		if err == nil {
+			output.Payload = payloads[i]
+		}
+
+		delivered++
+		p.deliver(ctx, &output)
+	}
+}
+
+// identify returns the error reported to the job of a batch. Since a
+// PanicError is shared by every job of the batch, each job receives its
+// own copy, identifying that job.
+func identify[I any](err error, job *Job[I]) error {
+	var pe *PanicError
+	if !errors.As(err, &pe) {
+		return err
+	}
+
+	identified := *pe
+	identified.ID, identified.SequenceNo = job.ID, job.SequenceNo
+
+	return &identified
+}
+
+// attempt invokes the batch function. When panic recovery is enabled, a
+// panicking batch results in a PanicError, shared by every job of the
+// batch, instead of the panic propagating to the worker.
+func (p *ManifoldBatchFuncPool[I, O]) attempt(bf ManifoldBatchFunc[I, O],
+	inputs []I,
+) (payloads []O, err error) {
+	if p.recoverPanics {
+		defer func() {
+			if pv := recover(); pv != nil {
+				payloads, err = nil, &PanicError{
+					Value: pv,
+					Stack: debug.Stack(),
+				}
+			}
+		}()
+	}
+
+	if payloads, err = bf(inputs); err == nil && len(payloads) != len(inputs) {
+		err = fmt.Errorf("%w (inputs: '%v', outputs: '%v')",
+			ErrBatchMismatch, len(inputs), len(payloads),
+		)
+	}
+
+	return payloads, err
+}
+
+// deliver records the outcome of the job and sends its output, ending
+// the job's lifetime.
+func (p *ManifoldBatchFuncPool[I, O]) deliver(ctx context.Context, output *JobOutput[O]) {
+	defer p.leave()
+
+	p.stats.outcome(output.Error)
+
+	if p.wi != nil {
+		_ = respond(ctx, p.wi, output)
+	}
+}
+
+// Source returns an input stream through which the client can submit
+// jobs to the pool. Using an input stream vs invoking Post is
+// mutually exclusive; that is to say, if Source is called, then Post
+// must not be called; any such invocations will be ignored.
+func (p *ManifoldBatchFuncPool[I, O]) Source(ctx context.Context,
+	wg WaitGroup,
+) SourceStreamW[I] {
+	o := p.pool.GetOptions()
+
+	p.basePool.inputDupCh = source(ctx, wg, o,
+		injector[I](func(input I) error {
+			return p.Post(ctx, input)
+		}),
+		terminator(func() {
+			p.Conclude(ctx)
+		}),
+	)
+
+	return p.basePool.inputDupCh.WriterCh
+}
+
+// Conclude signifies to the worker pool that no more work will be
+// submitted, at which point the final, possibly partial, batch is
+// dispatched without waiting for it to linger. The output channel is
+// closed as soon as the response of the last outstanding job has been
+// delivered.
+func (p *ManifoldBatchFuncPool[I, O]) Conclude(ctx context.Context) {
+	if batch := p.drain(); len(batch) > 0 {
+		go p.flush(ctx, batch)
+	}
+
+	if p.oi != nil {
+		p.conclude(ctx, p.closeOutput)
+	}
+}
+
+// Shutdown stops the pool from accepting any more jobs, either via Post
+// or Source, then waits up to the grace period for in-flight batches to
+// complete, returning ErrTimeout if they do not. The jobs that were never
+// started, including those of the batch still being gathered, are
+// returned so that the client can persist them. The output channel, if
+// any, is closed once the in-flight jobs have completed.
+func (p *ManifoldBatchFuncPool[I, O]) Shutdown(ctx context.Context,
+	grace time.Duration,
+) ([]Job[I], error) {
+	return p.shutdown(ctx, grace, func(ctx context.Context, timeout time.Duration) error {
+		for _, job := range p.drain() {
+			p.fail(job.SequenceNo)
+		}
+
+		return p.pool.ReleaseTimeout(ctx, timeout)
+	}, p.Conclude)
+}
+
+// Stats returns a snapshot of the statistics of the pool. Queue wait is
+// recorded for each job, whereas execution time is recorded for each
+// batch.
+func (p *ManifoldBatchFuncPool[I, O]) Stats() PoolStats {
+	return p.stats.snapshot(p.pool)
+}
@@ -0,0 +1,208 @@
+package boost_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // ginkgo ok
+	. "github.com/onsi/gomega"    //nolint:revive // gomega ok
+
+	"github.com/snivilised/lorax/boost"
+)
+
+var _ = Describe("WorkerPoolFuncBatch", func() {
+	double := func(inputs []int) ([]int, error) {
+		outputs := make([]int, len(inputs))
+		for i, input := range inputs {
+			outputs[i] = input * 2
+		}
+
+		return outputs, nil
+	}
+
+	When("batches fill up", func() {
+		It("🧪 should: fan out outputs by job", func(specCtx SpecContext) {
+			var (
+				wg    sync.WaitGroup
+				mutex sync.Mutex
+				sizes []int
+			)
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			pool, err := boost.NewManifoldBatchFuncPool(ctx,
+				func(inputs []int) ([]int, error) {
+					mutex.Lock()
+					sizes = append(sizes, len(inputs))
+					mutex.Unlock()
+
+					return double(inputs)
+				}, &wg,
+				boost.WithSize(2),
+				boost.WithOutput(10, 0, TimeoutOnSend),
+				boost.WithBatch(3, time.Minute),
+			)
+			Expect(err).To(Succeed())
+			defer pool.Release(ctx)
+
+			for i := 1; i <= 7; i++ {
+				Expect(pool.Post(ctx, i)).To(Succeed())
+			}
+			pool.Conclude(ctx)
+
+			payloads := map[int]int{}
+			for output := range pool.Observe() {
+				Expect(output.Error).To(Succeed())
+				Expect(output.ID).NotTo(BeEmpty())
+				payloads[output.SequenceNo] = output.Payload
+			}
+			Expect(payloads).To(HaveLen(7))
+
+			for seq, payload := range payloads {
+				Expect(payload).To(Equal(seq * 2))
+			}
+			Expect(sizes).To(ConsistOf(3, 3, 1))
+
+			stats := pool.Stats()
+			Expect(stats.Completed).To(BeEquivalentTo(7))
+			Expect(stats.Execution.Count).To(BeEquivalentTo(3))
+		})
+	})
+
+	When("batch lingers", func() {
+		It("🧪 should: dispatch partial batch", func(specCtx SpecContext) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			pool, err := boost.NewManifoldBatchFuncPool(ctx, double, &wg,
+				boost.WithOutput(10, 0, TimeoutOnSend),
+				boost.WithBatch(10, time.Millisecond*20),
+			)
+			Expect(err).To(Succeed())
+			defer pool.Release(ctx)
+
+			Expect(pool.Post(ctx, 21)).To(Succeed())
+
+			var output boost.JobOutput[int]
+			Eventually(pool.Observe()).Should(Receive(&output))
+			Expect(output.Payload).To(Equal(42))
+
+			pool.Conclude(ctx)
+			Eventually(pool.Observe()).Should(BeClosed())
+		})
+	})
+
+	When("batch exceeds reorder bound", func() {
+		It("🧪 should: dispatch partial batch rather than block", func(specCtx SpecContext) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithTimeout(specCtx, time.Second*5)
+			defer cancel()
+
+			pool, err := boost.NewManifoldBatchFuncPool(ctx, double, &wg,
+				boost.WithOutput(10, 0, TimeoutOnSend),
+				boost.WithOrderedOutput(3),
+				boost.WithBatch(10, 0),
+			)
+			Expect(err).To(Succeed())
+			defer pool.Release(ctx)
+
+			var payloads []int
+
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				for output := range pool.Observe() {
+					Expect(output.Error).To(Succeed())
+					payloads = append(payloads, output.Payload)
+				}
+			}()
+
+			for i := 1; i <= 7; i++ {
+				Expect(pool.Post(ctx, i)).To(Succeed())
+			}
+			pool.Conclude(ctx)
+			wg.Wait()
+
+			Expect(payloads).To(Equal([]int{2, 4, 6, 8, 10, 12, 14}))
+		})
+	})
+
+	When("batch function panics", func() {
+		It("🧪 should: identify each job in panic error", func(specCtx SpecContext) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			pool, err := boost.NewManifoldBatchFuncPool(ctx,
+				func([]int) ([]int, error) {
+					panic("batch panicked")
+				}, &wg,
+				boost.WithOutput(10, 0, TimeoutOnSend),
+				boost.WithRecoverPanics(true),
+				boost.WithBatch(2, 0),
+			)
+			Expect(err).To(Succeed())
+			defer pool.Release(ctx)
+
+			for i := 1; i <= 2; i++ {
+				Expect(pool.Post(ctx, i)).To(Succeed())
+			}
+			pool.Conclude(ctx)
+
+			for output := range pool.Observe() {
+				var pe *boost.PanicError
+				Expect(errors.As(output.Error, &pe)).To(BeTrue())
+				Expect(pe.ID).To(Equal(output.ID))
+				Expect(pe.SequenceNo).To(Equal(output.SequenceNo))
+			}
+		})
+	})
+
+	When("batch function fails", func() {
+		DescribeTable("🧪 should: report error for every job",
+			func(specCtx SpecContext, bf boost.ManifoldBatchFunc[int, int], expected error) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewManifoldBatchFuncPool(ctx, bf, &wg,
+					boost.WithOutput(10, 0, TimeoutOnSend),
+					boost.WithBatch(2, 0),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				for i := 1; i <= 2; i++ {
+					Expect(pool.Post(ctx, i)).To(Succeed())
+				}
+				pool.Conclude(ctx)
+
+				count := 0
+				for output := range pool.Observe() {
+					Expect(output.Error).To(MatchError(expected))
+					count++
+				}
+				Expect(count).To(Equal(2))
+				Expect(pool.Stats().Failed).To(BeEquivalentTo(2))
+			},
+			Entry(nil, func([]int) ([]int, error) {
+				return nil, errBatch
+			}, errBatch),
+			Entry(nil, func([]int) ([]int, error) {
+				return []int{1}, nil
+			}, boost.ErrBatchMismatch),
+		)
+	})
+})
+
+var errBatch = errors.New("batch failed")
@@ -0,0 +1,187 @@
+package boost
+
+import (
+	"context"
+	"runtime/debug"
+	"time"
+
+	"github.com/snivilised/lorax/internal/ants"
+)
+
+type (
+	// FuncE is the pre-defined function registered with the FuncPoolE,
+	// executed for each incoming job, returning only an error.
+	FuncE[I any] func(input I) error
+)
+
+// FuncPoolE is a functional worker pool with fire and return semantics.
+// Jobs do not emit an output, but any error they return is aggregated
+// into the PoolResult which is delivered on the completion stream once
+// the pool has finished.
+type FuncPoolE[I any] struct {
+	basePool[I, any]
+	functionalPool
+	completion
+}
+
+// NewFuncPoolE creates a new error returning function based worker pool.
+func NewFuncPoolE[I any](ctx context.Context,
+	fe FuncE[I],
+	wg WaitGroup,
+	options ...Option,
+) (*FuncPoolE[I], error) {
+	o := ants.NewOptions(options...)
+	if err := checkOptions[I, any](o,
+		withoutCapacity, withoutCache, withoutJournal, withoutPriority,
+		withoutDeduplication,
+	); err != nil {
+		return nil, err
+	}
+
+	r := newRetrier(o)
+	p := &FuncPoolE[I]{
+		basePool: basePool[I, any]{
+			wg:         wg,
+			limiter:    newLimiter(o),
+			middleware: middlewareFrom[I, any](o),
+		},
+		completion: newCompletion(),
+	}
+
+	pool, err := ants.NewPoolWithFunc(ctx, func(input InputParam) {
+		defer p.leave()
+
+		if job, ok := input.(Job[I]); ok {
+			p.started(job.SequenceNo)
+
+			err := p.stats.measure(job.posted, func() error {
+				return executeE(ctx, p.middleware, r, fe, job)
+			})
+			p.report(job.ID, job.SequenceNo, err)
+		}
+	}, ants.WithOptions(*o))
+
+	p.functionalPool = functionalPool{
+		pool: pool,
+	}
+	p.workers = pool
+
+	return p, err
+}
+
+// executeE invokes the error returning function with the input of the
+// job, as many times as allowed by the retry policy, via the chain of
+// middleware, if defined.
+func executeE[I any](ctx context.Context,
+	middleware []Middleware[I, any],
+	r *retrier,
+	fe FuncE[I],
+	job Job[I],
+) error {
+	handler := func(ctx context.Context, job Job[I]) JobOutput[any] {
+		attempts, err := r.do(ctx, func() error {
+			return attemptE(fe, job)
+		})
+
+		return JobOutput[any]{
+			ID:         job.ID,
+			SequenceNo: job.SequenceNo,
+			Error:      err,
+			Attempts:   attempts,
+		}
+	}
+
+	if len(middleware) > 0 {
+		handler = intercept(middleware, handler)
+	}
+
+	return handler(ctx, job).Error
+}
+
+// attemptE invokes the error returning function with the input of the
+// job. Since the PoolResult must account for every job, a panicking job
+// is always recovered and results in a PanicError, rather than the panic
+// propagating to the worker, where it would go unreported.
+func attemptE[I any](fe FuncE[I], job Job[I]) (err error) {
+	defer func() {
+		if pv := recover(); pv != nil {
+			err = &PanicError{
+				ID:         job.ID,
+				SequenceNo: job.SequenceNo,
+				Value:      pv,
+				Stack:      debug.Stack(),
+			}
+		}
+	}()
+
+	return fe(job.Input)
+}
+
+// Post allows the client to submit to the work pool represented by
+// input values of type I.
+func (p *FuncPoolE[I]) Post(ctx context.Context, input I) error {
+	if err := p.accepting(); err != nil {
+		return err
+	}
+
+	if err := p.throttle(ctx, ""); err != nil {
+		return err
+	}
+
+	o := p.pool.GetOptions()
+	job := Job[I]{
+		ID:         o.Generator.Generate(),
+		Input:      input,
+		SequenceNo: int(p.next()),
+		posted:     time.Now(),
+	}
+
+	return p.dispatch(job, func() error {
+		return p.pool.Invoke(ctx, job)
+	})
+}
+
+// Source returns an input stream through which the client can submit
+// jobs to the pool. Using an input stream vs invoking Post is
+// mutually exclusive; that is to say, if Source is called, then Post
+// must not be called; any such invocations will be ignored.
+func (p *FuncPoolE[I]) Source(ctx context.Context,
+	wg WaitGroup,
+) SourceStreamW[I] {
+	o := p.pool.GetOptions()
+
+	p.basePool.inputDupCh = source(ctx, wg, o,
+		injector[I](func(input I) error {
+			return p.Post(ctx, input)
+		}),
+		terminator(func() {
+			p.Conclude(ctx)
+		}),
+	)
+
+	return p.basePool.inputDupCh.WriterCh
+}
+
+// Conclude signifies to the worker pool that no more work will be
+// submitted. Once all outstanding jobs have completed, the PoolResult
+// is sent on the completion stream. When using Post, the client must
+// call Conclude, but when using Source, Conclude is invoked
+// automatically once the input channel has been closed.
+func (p *FuncPoolE[I]) Conclude(ctx context.Context) {
+	p.conclude(ctx, p.complete)
+}
+
+// Shutdown stops the pool from accepting any more jobs and waits up to
+// the grace period for in-flight jobs to complete, returning the jobs
+// that were never started. The PoolResult is delivered once the in-flight
+// jobs have completed.
+func (p *FuncPoolE[I]) Shutdown(ctx context.Context,
+	grace time.Duration,
+) ([]Job[I], error) {
+	return p.shutdown(ctx, grace, p.pool.ReleaseTimeout, p.Conclude)
+}
+
+// Stats returns a snapshot of the statistics of the pool.
+func (p *FuncPoolE[I]) Stats() PoolStats {
+	return p.stats.snapshot(p.pool)
+}
@@ -0,0 +1,154 @@
+package boost_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // ginkgo ok
+	. "github.com/onsi/gomega"    //nolint:revive // gomega ok
+
+	"github.com/snivilised/lorax/boost"
+)
+
+func failOdd(input int) error {
+	if input%2 == 1 {
+		return errOddInput
+	}
+
+	return nil
+}
+
+var _ = Describe("WorkerPoolFuncE", func() {
+	Context("ants", func() {
+		When("all jobs succeed", func() {
+			It("🧪 should: deliver successful pool result", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewFuncPoolE(ctx, func(int) error {
+					return nil
+				}, &wg,
+					boost.WithSize(PoolSize),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				for i := 0; i < 100; i++ {
+					Expect(pool.Post(ctx, i)).To(Succeed())
+				}
+				pool.Conclude(ctx)
+
+				result := <-pool.Completion()
+				wg.Wait()
+
+				Expect(result.Error).To(Succeed())
+				Expect(result.FirstFailure).To(BeNil())
+			})
+		})
+
+		When("some jobs fail", func() {
+			It("🧪 should: join all job errors", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewFuncPoolE(ctx, failOdd, &wg,
+					boost.WithSize(PoolSize),
+					boost.WithInput(InputBufferSize),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				ch := pool.Source(ctx, &wg)
+				for i := 0; i < 10; i++ {
+					ch <- i
+				}
+				close(ch)
+
+				result := <-pool.Completion()
+				wg.Wait()
+
+				Expect(result.Error).To(MatchError(errOddInput))
+				Expect(result.FirstFailure).NotTo(BeNil())
+				Expect(result.FirstFailure.ID).NotTo(BeEmpty())
+				Expect(result.FirstFailure.SequenceNo%2).To(Equal(0),
+					"sequence numbers start at 1, so odd inputs have even sequence numbers",
+				)
+
+				var joined interface{ Unwrap() []error }
+				Expect(errors.As(result.Error, &joined)).To(BeTrue())
+				Expect(joined.Unwrap()).To(HaveLen(5))
+			})
+		})
+
+		When("cancelled", func() {
+			It("🧪 should: reflect cancellation in pool result", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewFuncPoolE(ctx, func(int) error {
+					<-ctx.Done()
+
+					return nil
+				}, &wg,
+					boost.WithSize(PoolSize),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				Expect(pool.Post(ctx, Param)).To(Succeed())
+				pool.Conclude(ctx)
+				cancel()
+
+				result := <-pool.Completion()
+				wg.Wait()
+
+				Expect(result.Error).To(MatchError(context.Canceled))
+			})
+		})
+
+		When("a job panics", func() {
+			It("🧪 should: report panic as first failure", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewFuncPoolE(ctx, func(input int) error {
+					if input == 1 {
+						panic("boom")
+					}
+
+					return nil
+				}, &wg,
+					boost.WithSize(1),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				for i := 0; i < 3; i++ {
+					Expect(pool.Post(ctx, i)).To(Succeed())
+				}
+				pool.Conclude(ctx)
+
+				result := <-pool.Completion()
+				wg.Wait()
+
+				Expect(result.Error).To(MatchError(boost.ErrJobPanic))
+				Expect(result.FirstFailure).NotTo(BeNil())
+				Expect(result.FirstFailure.SequenceNo).To(Equal(2))
+
+				var panicErr *boost.PanicError
+				Expect(errors.As(result.Error, &panicErr)).To(BeTrue())
+				Expect(panicErr.ID).To(Equal(result.FirstFailure.ID))
+				Expect(panicErr.SequenceNo).To(Equal(2))
+			})
+		})
+	})
+})
@@ -11,6 +11,11 @@ type (
 	// ManifoldFunc is the pre-defined function registered with the worker
 	// pool, executed for each incoming job.
 	ManifoldFunc[I, O any] func(input I) (O, error)
+
+	// ManifoldFuncCtx is the context aware equivalent of ManifoldFunc. The
+	// context is cancelled when the pool's context is cancelled or when the
+	// job's deadline is reached, whichever happens first.
+	ManifoldFuncCtx[I, O any] func(ctx context.Context, input I) (O, error)
 )
 
 // ManifoldFuncPool is a wrapper around the underlying ants function based
@@ -22,6 +27,10 @@ type (
 type ManifoldFuncPool[I, O any] struct {
 	basePool[I, O]
 	functionalPool
+	scheduler *scheduler[I]
+	keyFunc   KeyFunc[I]
+	journal   *Journal[I]
+	exec      *executor[I, O]
 }
 
 // NewManifoldFuncPool creates a new manifold function based worker pool.
@@ -30,42 +39,308 @@ func NewManifoldFuncPool[I, O any](ctx context.Context,
 	wg WaitGroup,
 	options ...Option,
 ) (*ManifoldFuncPool[I, O], error) {
-	var (
-		oi *outputInfo[O]
-		wi *outputInfoW[O]
-		o  = ants.NewOptions(options...)
-	)
+	return NewManifoldFuncPoolCtx(ctx, withContext(mf), wg, options...)
+}
 
-	if oi = newOutputInfo[O](o); oi != nil {
-		wi = fromOutputInfo(o, oi)
+// NewManifoldFuncPoolCtx creates a new manifold function based worker pool,
+// whose manifold function is context aware, allowing long running jobs to
+// observe cancellation of the pool and expiry of their deadline.
+func NewManifoldFuncPoolCtx[I, O any](ctx context.Context,
+	mf ManifoldFuncCtx[I, O],
+	wg WaitGroup,
+	options ...Option,
+) (*ManifoldFuncPool[I, O], error) {
+	o := ants.NewOptions(options...)
+	if err := checkOptions[I, O](o); err != nil {
+		return nil, err
 	}
 
+	p := &ManifoldFuncPool[I, O]{
+		basePool: basePool[I, O]{
+			wg:       wg,
+			limiter:  newLimiter(o),
+			capacity: newCapacity(o),
+		},
+		keyFunc: keyFuncFrom[I](o),
+		journal: journalFrom[I](o),
+	}
+
+	exec := newExecutor[I](o, p.outputs(ctx, o), &p.stats)
+	p.exec = exec
 	pool, err := ants.NewPoolWithFunc(ctx, func(input InputParam) {
-		manifoldFuncResponse(ctx, mf, input, wi)
+		defer p.leave()
+		defer p.complete(input)
+
+		p.invoke(ctx, exec, mf, input)
 	}, ants.WithOptions(*o))
 
-	return &ManifoldFuncPool[I, O]{
-		basePool: basePool[I, O]{
-			wg: wg,
-			oi: oi,
-		},
-		functionalPool: functionalPool{
-			pool: pool,
-		},
-	}, err
+	p.functionalPool = functionalPool{
+		pool: pool,
+	}
+	p.workers = pool
+
+	if o.Priority != nil && err == nil {
+		p.scheduler = newScheduler[I](o.Priority.Aging)
+		p.scheduler.run(ctx, wg,
+			func(job Job[I]) {
+				if e := p.pool.Invoke(ctx, job); e != nil {
+					if p.accepting() != nil {
+						p.discard(job)
+
+						return
+					}
+
+					p.unweigh(job.Weight)
+					exec.reject(ctx, &job, e)
+					p.retract(&job)
+					p.started(job.SequenceNo)
+					p.leave()
+				}
+			},
+			p.discard,
+		)
+	}
+
+	return p, err
+}
+
+// ResumeManifoldFuncPool creates a new manifold function based worker
+// pool that records its jobs in the journal, then re-submits the jobs
+// that were incomplete when the journal was opened. The re-submitted jobs
+// are assigned a new ID and SequenceNo, but retain their Input and
+// Priority. Re-submission takes place in the background, so the client
+// is free to consume the output and post further jobs straight away;
+// the pool does not finish until all incomplete jobs have been
+// re-submitted, even if concluded beforehand. Should a job fail to be
+// re-submitted, eg because the context is cancelled, it and the jobs
+// after it remain incomplete in the journal.
+func ResumeManifoldFuncPool[I, O any](ctx context.Context,
+	mf ManifoldFunc[I, O],
+	wg WaitGroup,
+	journal *Journal[I],
+	options ...Option,
+) (*ManifoldFuncPool[I, O], error) {
+	return ResumeManifoldFuncPoolCtx(ctx, withContext(mf), wg, journal, options...)
+}
+
+// ResumeManifoldFuncPoolCtx is the context aware equivalent of
+// ResumeManifoldFuncPool.
+func ResumeManifoldFuncPoolCtx[I, O any](ctx context.Context,
+	mf ManifoldFuncCtx[I, O],
+	wg WaitGroup,
+	journal *Journal[I],
+	options ...Option,
+) (*ManifoldFuncPool[I, O], error) {
+	p, err := NewManifoldFuncPoolCtx(ctx, mf, wg,
+		append(options, WithJournal(journal))...,
+	)
+	if err != nil {
+		return p, err
+	}
+
+	p.resume(ctx, wg, journal.Incomplete())
+
+	return p, nil
+}
+
+// resume re-submits the jobs in the background. The re-submission is
+// itself treated as an in-flight job, so that the pool can not finish
+// until it is complete.
+func (p *ManifoldFuncPool[I, O]) resume(ctx context.Context, wg WaitGroup, jobs []Job[I]) {
+	p.enter()
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		defer p.leave()
+
+		for _, job := range jobs {
+			job.ID = ""
+
+			if err := p.post(ctx, job); err != nil {
+				return
+			}
+		}
+	}()
 }
 
 // Post allows the client to submit to the work pool represented by
 // input values of type I.
 func (p *ManifoldFuncPool[I, O]) Post(ctx context.Context, input I) error {
+	return p.PostJob(ctx, Job[I]{
+		Input: input,
+	})
+}
+
+// PostJob allows the client to submit a job to the work pool, which
+// enables the job's optional properties, such as its Deadline, to be
+// defined. The ID and SequenceNo are always assigned by the pool. When
+// priority scheduling is enabled, the job is queued and PostJob returns
+// immediately; the blocking options then apply to the dispatching of
+// queued jobs, rather than to the client. When ordered output is
+// enabled, PostJob blocks whilst the reorder buffer is at its bound. When
+// a rate limit is defined, PostJob waits for a token before the job is
+// dispatched or, if the limit is non-blocking, returns a RateLimitError.
+// When a capacity is defined, PostJob blocks until there is enough
+// capacity to accommodate the job's Weight. When a result cache is
+// defined and holds the payload for the job's key, the output is emitted
+// straight away, without the job being dispatched to a worker.
+func (p *ManifoldFuncPool[I, O]) PostJob(ctx context.Context, job Job[I]) error {
+	job.ID, job.entry = "", 0
+
+	return p.post(ctx, job)
+}
+
+// post submits the job to the pool. A job replayed from the journal
+// retains its journal entry, so that it is not recorded again, and a
+// job relayed by a pipeline retains the ID it was assigned on entry to
+// the pipeline.
+func (p *ManifoldFuncPool[I, O]) post(ctx context.Context, job Job[I]) error {
+	if err := p.accepting(); err != nil {
+		return err
+	}
+
+	if p.exec.memo != nil {
+		if payload, found := p.exec.memo.get(job.Input); found {
+			p.stats.cacheHit()
+
+			return p.recall(ctx, job, payload)
+		}
+
+		p.stats.cacheMiss()
+	}
+
+	if err := p.throttle(ctx, keyOf(p.keyFunc, job.Input)); err != nil {
+		return err
+	}
+
+	if err := p.weigh(ctx, job.Weight); err != nil {
+		return err
+	}
+
+	if err := p.admit(ctx); err != nil {
+		p.unweigh(job.Weight)
+
+		return err
+	}
+
 	o := p.pool.GetOptions()
-	job := Job[I]{
-		ID:         o.Generator.Generate(),
-		Input:      input,
-		SequenceNo: int(p.next()),
+	if job.ID == "" {
+		job.ID = o.Generator.Generate()
+	}
+
+	job.SequenceNo = int(p.next())
+	job.posted = time.Now()
+
+	if p.scheduler != nil {
+		p.hold(job)
+		p.enter()
+
+		if err := p.record(&job); err != nil {
+			p.unweigh(job.Weight)
+			p.fail(job.SequenceNo)
+
+			return err
+		}
+
+		p.stats.submit()
+		p.scheduler.push(job)
+
+		return nil
+	}
+
+	replayed := job.entry != 0
+	err := p.dispatch(job, func() error {
+		if err := p.record(&job); err != nil {
+			return err
+		}
+
+		err := p.pool.Invoke(ctx, job)
+		if err != nil && !replayed {
+			p.retract(&job)
+		}
+
+		return err
+	})
+	if err != nil {
+		p.unweigh(job.Weight)
+	}
+
+	return err
+}
+
+// recall emits the cached payload as the output of the job. The job is
+// not dispatched to a worker, nor is it subject to the rate limit or the
+// capacity, but it is still assigned an ID and SequenceNo and takes its
+// place in the ordered output, if enabled.
+func (p *ManifoldFuncPool[I, O]) recall(ctx context.Context, job Job[I], payload O) error {
+	if err := p.admit(ctx); err != nil {
+		return err
+	}
+
+	if job.ID == "" {
+		job.ID = p.pool.GetOptions().Generator.Generate()
+	}
+
+	job.SequenceNo = int(p.next())
+
+	p.enter()
+	defer p.leave()
+
+	p.stats.submit()
+	p.exec.recall(ctx, &job, payload)
+
+	// a job replayed from the journal is now complete
+	if p.journal != nil && job.entry != 0 {
+		p.journal.completed(&job)
+	}
+
+	return nil
+}
+
+// record writes the job to the journal, if there is one.
+func (p *ManifoldFuncPool[I, O]) record(job *Job[I]) error {
+	if p.journal != nil {
+		return p.journal.posted(job)
 	}
 
-	return p.pool.Invoke(ctx, job)
+	return nil
+}
+
+// retract records the completion of a job that was recorded in the
+// journal, but then rejected by the underlying pool. The client has been
+// informed of its failure, so it must not be replayed. A job rejected
+// because the pool is being shut down remains incomplete, since it is
+// returned by Shutdown as never having started.
+func (p *ManifoldFuncPool[I, O]) retract(job *Job[I]) {
+	if p.journal != nil && p.accepting() == nil {
+		p.journal.completed(job)
+	}
+}
+
+// complete releases the weight of the job and records its completion in
+// the journal, if there is one.
+func (p *ManifoldFuncPool[I, O]) complete(input InputParam) {
+	job, ok := input.(Job[I])
+	if !ok {
+		return
+	}
+
+	p.unweigh(job.Weight)
+
+	if p.journal != nil {
+		p.journal.completed(&job)
+	}
+}
+
+// discard ends the lifetime of a job queued by the scheduler that will
+// never be started. Since the job was counted as submitted when it was
+// queued, it is withdrawn, as it would have been had it been rejected
+// by the underlying pool.
+func (p *ManifoldFuncPool[I, O]) discard(job Job[I]) {
+	p.stats.withdraw()
+	p.unweigh(job.Weight)
+	p.fail(job.SequenceNo)
 }
 
 // Source returns an input stream through which the client can submit
@@ -96,52 +371,42 @@ func (p *ManifoldFuncPool[I, O]) Source(ctx context.Context,
 // to use an input channel, by invoking Source, then Conclude will
 // be called automatically as long as the input channel has been closed.
 // Failure to close the channel will again result in a never ending
-// worker pool.
+// worker pool. The output channel is closed as soon as the response
+// of the last outstanding job has been delivered.
 func (p *ManifoldFuncPool[I, O]) Conclude(ctx context.Context) {
-	if p.oi != nil && !p.ending {
-		p.ending = true
-		o := p.pool.GetOptions()
-		interval := max(o.Output.CheckCloseInterval, ants.MinimumCheckCloseInterval)
-
-		p.wg.Add(1)
-		go func(ctx context.Context,
-			pool *ManifoldFuncPool[I, O],
-			wg WaitGroup,
-			interval time.Duration,
-		) {
-			defer wg.Done()
-
-			for {
-				select {
-				case <-ctx.Done():
-					return
-
-				case <-time.After(interval):
-					if pool.Running() == 0 && pool.Waiting() == 0 {
-						close(p.oi.outputDupCh.Channel)
-						return
-					}
-				}
-			}
-		}(ctx, p, p.wg, interval)
+	if p.scheduler != nil {
+		p.scheduler.close()
+	}
+
+	if p.oi != nil {
+		p.conclude(ctx, p.closeOutput)
 	}
 }
 
-func manifoldFuncResponse[I, O any](ctx context.Context,
-	mf ManifoldFunc[I, O],
-	input InputParam,
-	wi *outputInfoW[O],
-) {
-	if job, ok := input.(Job[I]); ok {
-		payload, e := mf(job.Input)
-
-		if wi != nil {
-			_ = respond(ctx, wi, &JobOutput[O]{
-				ID:         job.ID,
-				SequenceNo: job.SequenceNo,
-				Payload:    payload,
-				Error:      e,
-			})
+// Shutdown stops the pool from accepting any more jobs, either via Post
+// or Source, then waits up to the grace period for in-flight jobs to
+// complete, returning ErrTimeout if they do not. The jobs that were never
+// started, including those still queued by the priority scheduler, are
+// returned so that the client can persist them. The output channel, if
+// any, is closed once the in-flight jobs have completed.
+func (p *ManifoldFuncPool[I, O]) Shutdown(ctx context.Context,
+	grace time.Duration,
+) ([]Job[I], error) {
+	return p.shutdown(ctx, grace, func(ctx context.Context, timeout time.Duration) error {
+		if p.scheduler != nil {
+			for _, job := range p.scheduler.drain() {
+				p.discard(job)
+			}
 		}
-	}
+
+		return p.pool.ReleaseTimeout(ctx, timeout)
+	}, p.Conclude)
+}
+
+// Stats returns a snapshot of the statistics of the pool.
+func (p *ManifoldFuncPool[I, O]) Stats() PoolStats {
+	stats := p.stats.snapshot(p.pool)
+	stats.InUseWeight = p.InUseWeight()
+
+	return stats
 }
@@ -2,8 +2,15 @@ package boost_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"runtime"
+	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
 
 	. "github.com/onsi/ginkgo/v2" //nolint:revive // ginkgo ok
 	. "github.com/onsi/gomega"    //nolint:revive // gomega ok
@@ -232,6 +239,1170 @@ var _ = Describe("WorkerPoolFuncManifold", func() {
 			})
 		})
 
+		Context("Conclude", func() {
+			When("last outstanding job completes", func() {
+				It("🧪 should: close output channel without delay", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldFuncPool(
+						ctx, func(input int) (int, error) {
+							return input, nil
+						}, &wg,
+						boost.WithSize(PoolSize),
+						boost.WithExpiryDuration(time.Minute),
+						boost.WithOutput(10, 0, TimeoutOnSend),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					for i := 0; i < 10; i++ {
+						Expect(pool.Post(ctx, i)).To(Succeed())
+					}
+					pool.Conclude(ctx)
+
+					count := 0
+					start := time.Now()
+					for range pool.Observe() {
+						count++
+					}
+
+					Expect(count).To(Equal(10))
+					Expect(time.Since(start)).To(BeNumerically("<", time.Second/2),
+						"closure should not wait for idle workers to be purged",
+					)
+				})
+			})
+
+			When("invoked concurrently", func() {
+				It("🧪 should: close output channel once", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldFuncPool(
+						ctx, func(input int) (int, error) {
+							return input, nil
+						}, &wg,
+						boost.WithSize(PoolSize),
+						boost.WithOutput(10, 0, TimeoutOnSend),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					Expect(pool.Post(ctx, 1)).To(Succeed())
+
+					var concluders sync.WaitGroup
+					for i := 0; i < 8; i++ {
+						concluders.Add(1)
+						go func() {
+							defer GinkgoRecover()
+							defer concluders.Done()
+
+							pool.Conclude(ctx)
+						}()
+					}
+					concluders.Wait()
+
+					Eventually(pool.Observe()).Should(Receive())
+					Eventually(pool.Observe()).Should(BeClosed())
+				})
+			})
+		})
+
+		Context("ManifoldFuncCtx", func() {
+			When("job overruns per-job timeout", func() {
+				It("🧪 should: emit timeout error", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldFuncPoolCtx(
+						ctx, func(ctx context.Context, input int) (int, error) {
+							if input == 0 {
+								<-ctx.Done()
+
+								return input, ctx.Err()
+							}
+
+							return input, nil
+						}, &wg,
+						boost.WithSize(PoolSize),
+						boost.WithJobTimeout(time.Millisecond*20),
+						boost.WithOutput(10, 0, TimeoutOnSend),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					Expect(pool.Post(ctx, 0)).To(Succeed())
+					Expect(pool.Post(ctx, 1)).To(Succeed())
+					pool.Conclude(ctx)
+
+					timeouts := 0
+					for output := range pool.Observe() {
+						if output.Error != nil {
+							var te *boost.JobTimeoutError
+
+							Expect(output.Error).To(MatchError(boost.ErrJobTimeout))
+							Expect(errors.As(output.Error, &te)).To(BeTrue())
+							Expect(te.SequenceNo).To(Equal(output.SequenceNo))
+							timeouts++
+						}
+					}
+					Expect(timeouts).To(Equal(1))
+				})
+			})
+
+			When("job defines deadline", func() {
+				It("🧪 should: emit timeout error only for overrunning job", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldFuncPool(
+						ctx, func(input int) (int, error) {
+							time.Sleep(time.Duration(input) * time.Millisecond)
+
+							return input, nil
+						}, &wg,
+						boost.WithSize(PoolSize),
+						boost.WithOutput(10, 0, TimeoutOnSend),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					Expect(pool.PostJob(ctx, boost.Job[int]{
+						Input:    50,
+						Deadline: time.Now().Add(time.Millisecond * 10),
+					})).To(Succeed())
+					Expect(pool.PostJob(ctx, boost.Job[int]{
+						Input:    1,
+						Deadline: time.Now().Add(time.Second),
+					})).To(Succeed())
+					pool.Conclude(ctx)
+
+					outputs := map[int]error{}
+					for output := range pool.Observe() {
+						outputs[output.SequenceNo] = output.Error
+					}
+					Expect(outputs[1]).To(MatchError(boost.ErrJobTimeout))
+					Expect(outputs[2]).To(Succeed())
+				})
+			})
+		})
+
+		Context("RecoverPanics", func() {
+			When("job panics", func() {
+				It("🧪 should: emit output with panic error", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldFuncPool(
+						ctx, func(input int) (int, error) {
+							if input == 3 {
+								panic("boom")
+							}
+
+							return input, nil
+						}, &wg,
+						boost.WithSize(PoolSize),
+						boost.WithRecoverPanics(true),
+						boost.WithOutput(10, 0, TimeoutOnSend),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					for i := 0; i < 5; i++ {
+						Expect(pool.Post(ctx, i)).To(Succeed())
+					}
+					pool.Conclude(ctx)
+
+					var (
+						count  int
+						panics []*boost.PanicError
+					)
+
+					for output := range pool.Observe() {
+						count++
+
+						var pe *boost.PanicError
+						if errors.As(output.Error, &pe) {
+							Expect(output.Error).To(MatchError(boost.ErrJobPanic))
+							Expect(pe.SequenceNo).To(Equal(output.SequenceNo))
+							Expect(pe.ID).To(Equal(output.ID))
+							panics = append(panics, pe)
+						}
+					}
+
+					Expect(count).To(Equal(5))
+					Expect(panics).To(HaveLen(1))
+					Expect(panics[0].Value).To(Equal("boom"))
+					Expect(panics[0].Stack).NotTo(BeEmpty())
+				})
+			})
+		})
+
+		Context("Retry", func() {
+			var errTransient = errors.New("transient")
+
+			DescribeTable("retry policy",
+				func(specCtx SpecContext, policy boost.RetryPolicy, failures, expected int, succeed bool) {
+					var (
+						wg    sync.WaitGroup
+						calls int32
+					)
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldFuncPool(
+						ctx, func(input int) (int, error) {
+							if int(atomic.AddInt32(&calls, 1)) <= failures {
+								return 0, errTransient
+							}
+
+							return input, nil
+						}, &wg,
+						boost.WithSize(1),
+						boost.WithRetry(policy),
+						boost.WithOutput(10, 0, TimeoutOnSend),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					Expect(pool.Post(ctx, Param)).To(Succeed())
+					pool.Conclude(ctx)
+
+					outputs := []boost.JobOutput[int]{}
+					for output := range pool.Observe() {
+						outputs = append(outputs, output)
+					}
+
+					Expect(outputs).To(HaveLen(1), "output emitted only after final attempt")
+					Expect(outputs[0].Attempts).To(Equal(expected))
+
+					if succeed {
+						Expect(outputs[0].Error).To(Succeed())
+						Expect(outputs[0].Payload).To(Equal(Param))
+					} else {
+						Expect(outputs[0].Error).To(MatchError(errTransient))
+					}
+				},
+				func(_ boost.RetryPolicy, failures, expected int, succeed bool) string {
+					return fmt.Sprintf("failures: %v, attempts: %v, succeed: %v",
+						failures, expected, succeed,
+					)
+				},
+				Entry(nil, boost.RetryPolicy{
+					MaxAttempts: 3,
+					BackOff: func() backoff.BackOff {
+						return backoff.NewConstantBackOff(time.Millisecond)
+					},
+				}, 2, 3, true),
+				Entry(nil, boost.RetryPolicy{
+					MaxAttempts: 2,
+				}, 5, 2, false),
+				Entry(nil, boost.RetryPolicy{
+					MaxAttempts: 5,
+					ShouldRetry: func(error) bool {
+						return false
+					},
+				}, 1, 1, false),
+			)
+		})
+
+		Context("Priority", func() {
+			const blocker = -1
+
+			// schedule runs jobs on a single worker, making sure that the
+			// worker is busy and the dispatcher is blocked, before the
+			// jobs are posted, so that they all accumulate in the queue.
+			schedule := func(ctx context.Context, aging time.Duration,
+				jobs func(pool *boost.ManifoldFuncPool[int, int]),
+			) []int {
+				var (
+					wg      sync.WaitGroup
+					order   []int
+					started = make(chan struct{})
+					release = make(chan struct{})
+				)
+
+				pool, err := boost.NewManifoldFuncPool(
+					ctx, func(input int) (int, error) {
+						if input == blocker {
+							close(started)
+							<-release
+						}
+
+						return input, nil
+					}, &wg,
+					boost.WithSize(1),
+					boost.WithPriority(aging),
+					boost.WithOutput(10, 0, TimeoutOnSend),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				Expect(pool.Post(ctx, blocker)).To(Succeed())
+				<-started
+				Expect(pool.Post(ctx, 0)).To(Succeed())
+				Eventually(pool.Waiting).Should(Equal(1))
+
+				jobs(pool)
+				close(release)
+				pool.Conclude(ctx)
+
+				for output := range pool.Observe() {
+					order = append(order, output.Payload)
+				}
+				wg.Wait()
+
+				return order
+			}
+
+			When("jobs have different priorities", func() {
+				It("🧪 should: dispatch higher priority first", func(specCtx SpecContext) {
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					order := schedule(ctx, 0, func(pool *boost.ManifoldFuncPool[int, int]) {
+						for _, job := range []boost.Job[int]{
+							{Input: 1, Priority: 1},
+							{Input: 2, Priority: 1},
+							{Input: 10, Priority: 10},
+							{Input: 5, Priority: 5},
+						} {
+							Expect(pool.PostJob(ctx, job)).To(Succeed())
+						}
+					})
+
+					Expect(order).To(Equal([]int{blocker, 0, 10, 5, 1, 2}))
+				})
+			})
+
+			When("low priority job has aged", func() {
+				It("🧪 should: dispatch aged job first", func(specCtx SpecContext) {
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					order := schedule(ctx, time.Millisecond, func(pool *boost.ManifoldFuncPool[int, int]) {
+						Expect(pool.PostJob(ctx, boost.Job[int]{
+							Input: 1,
+						})).To(Succeed())
+
+						time.Sleep(time.Millisecond * 50)
+
+						Expect(pool.PostJob(ctx, boost.Job[int]{
+							Input:    5,
+							Priority: 5,
+						})).To(Succeed())
+					})
+
+					Expect(order).To(Equal([]int{blocker, 0, 1, 5}))
+				})
+			})
+		})
+
+		Context("Shutdown", func() {
+			// create returns a pool with a single worker, which is blocked
+			// by the first job until the returned release function is called.
+			create := func(ctx context.Context, wg *sync.WaitGroup,
+				options ...boost.Option,
+			) (*boost.ManifoldFuncPool[int, int], func()) {
+				var (
+					started = make(chan struct{})
+					release = make(chan struct{})
+					once    sync.Once
+				)
+
+				pool, err := boost.NewManifoldFuncPool(
+					ctx, func(input int) (int, error) {
+						if input == 0 {
+							close(started)
+							<-release
+						}
+
+						return input, nil
+					}, wg,
+					append([]boost.Option{
+						boost.WithSize(1),
+						boost.WithOutput(10, 0, TimeoutOnSend),
+					}, options...)...,
+				)
+				Expect(err).To(Succeed())
+
+				Expect(pool.Post(ctx, 0)).To(Succeed())
+				<-started
+
+				return pool, func() {
+					once.Do(func() {
+						close(release)
+					})
+				}
+			}
+
+			inputs := func(jobs []boost.Job[int]) []int {
+				result := make([]int, 0, len(jobs))
+				for _, job := range jobs {
+					result = append(result, job.Input)
+				}
+
+				return result
+			}
+
+			When("jobs are blocked waiting for a worker", func() {
+				It("🧪 should: return unstarted jobs", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, release := create(ctx, &wg)
+
+					errs := make(chan error, 2)
+					for i := 1; i <= 2; i++ {
+						go func(input int) {
+							errs <- pool.Post(ctx, input)
+						}(i)
+						Eventually(pool.Waiting).Should(Equal(i))
+					}
+
+					time.AfterFunc(time.Millisecond*20, release)
+					unstarted, err := pool.Shutdown(ctx, time.Second)
+					Expect(err).To(Succeed())
+					Expect(inputs(unstarted)).To(Equal([]int{1, 2}))
+					Expect(unstarted[0].ID).NotTo(BeEmpty())
+
+					Expect(<-errs).To(MatchError(boost.ErrPoolClosed))
+					Expect(<-errs).To(MatchError(boost.ErrPoolClosed))
+					Expect(pool.Post(ctx, 3)).To(MatchError(boost.ErrPoolClosed))
+
+					var payloads []int
+					for output := range pool.Observe() {
+						payloads = append(payloads, output.Payload)
+					}
+					Expect(payloads).To(Equal([]int{0}))
+				})
+			})
+
+			When("jobs are queued by the scheduler", func() {
+				It("🧪 should: return unstarted jobs", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, release := create(ctx, &wg, boost.WithPriority(0))
+					defer release()
+
+					for i := 1; i <= 3; i++ {
+						Expect(pool.PostJob(ctx, boost.Job[int]{
+							Input:    i,
+							Priority: i,
+						})).To(Succeed())
+					}
+					Eventually(pool.Waiting).Should(Equal(1))
+
+					time.AfterFunc(time.Millisecond*20, release)
+					unstarted, err := pool.Shutdown(ctx, time.Second)
+					Expect(err).To(Succeed())
+					Expect(inputs(unstarted)).To(ConsistOf(1, 2, 3))
+
+					for range pool.Observe() {
+					}
+					wg.Wait()
+
+					stats := pool.Stats()
+					Expect(stats.Submitted).To(BeEquivalentTo(1))
+					Expect(stats.Completed + stats.Failed).To(Equal(stats.Submitted))
+				})
+			})
+
+			When("in-flight jobs overrun grace period", func() {
+				It("🧪 should: return timeout error", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, release := create(ctx, &wg)
+					defer release()
+
+					_, err := pool.Shutdown(ctx, time.Millisecond*20)
+					Expect(err).To(MatchError(boost.ErrTimeout))
+
+					_, err = pool.Shutdown(ctx, time.Millisecond*20)
+					Expect(err).To(MatchError(boost.ErrPoolClosed))
+				})
+			})
+		})
+
+		Context("CircuitBreaker", func() {
+			type transition struct {
+				from, to boost.CircuitState
+			}
+
+			var (
+				mutex       sync.Mutex
+				transitions []transition
+			)
+
+			BeforeEach(func() {
+				transitions = nil
+			})
+
+			onStateChange := func(from, to boost.CircuitState) {
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				transitions = append(transitions, transition{from, to})
+			}
+
+			const pause = -1
+
+			// execute posts each input in turn to a single worker, waiting
+			// for its output before posting the next, returning the errors.
+			// The pause input is not posted, instead it waits for the cool
+			// down period to elapse.
+			execute := func(ctx context.Context, cb boost.CircuitBreaker,
+				fn boost.ManifoldFunc[int, string],
+				inputs ...int,
+			) []error {
+				var wg sync.WaitGroup
+
+				cb.OnStateChange = onStateChange
+				pool, err := boost.NewManifoldFuncPool(
+					ctx, fn, &wg,
+					boost.WithSize(1),
+					boost.WithCircuitBreaker(cb),
+					boost.WithOutput(10, 0, TimeoutOnSend),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				errs := make([]error, 0, len(inputs))
+				for _, input := range inputs {
+					if input == pause {
+						time.Sleep(cb.CoolDown)
+
+						continue
+					}
+
+					Expect(pool.Post(ctx, input)).To(Succeed())
+					errs = append(errs, (<-pool.Observe()).Error)
+				}
+				pool.Conclude(ctx)
+				wg.Wait()
+
+				return errs
+			}
+
+			When("consecutive failures reach threshold", func() {
+				It("🧪 should: short-circuit subsequent jobs", func(specCtx SpecContext) {
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					errs := execute(ctx, boost.CircuitBreaker{
+						ConsecutiveFailures: 3,
+						CoolDown:            time.Hour,
+					}, rejectOdd, 1, 3, 5, 7, 2)
+
+					Expect(errs[:3]).To(HaveEach(MatchError(errOddInput)))
+					Expect(errs[3:]).To(HaveEach(MatchError(boost.ErrCircuitOpen)))
+					Expect(transitions).To(Equal([]transition{
+						{boost.CircuitClosed, boost.CircuitOpen},
+					}))
+				})
+			})
+
+			When("failure rate reaches threshold", func() {
+				It("🧪 should: short-circuit subsequent jobs", func(specCtx SpecContext) {
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					errs := execute(ctx, boost.CircuitBreaker{
+						FailureRate: 0.5,
+						MinRequests: 4,
+						CoolDown:    time.Hour,
+					}, rejectOdd, 0, 1, 2, 3, 4)
+
+					Expect(errs[3]).To(MatchError(errOddInput))
+					Expect(errs[4]).To(MatchError(boost.ErrCircuitOpen))
+				})
+			})
+
+			When("cool down has elapsed", func() {
+				It("🧪 should: close after successful probe", func(specCtx SpecContext) {
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					errs := execute(ctx, boost.CircuitBreaker{
+						ConsecutiveFailures: 1,
+						CoolDown:            time.Millisecond * 20,
+					}, rejectOdd, 1, 2, pause, 2, 4)
+
+					Expect(errs[0]).To(MatchError(errOddInput))
+					Expect(errs[1]).To(MatchError(boost.ErrCircuitOpen))
+					Expect(errs[2:]).To(HaveEach(Succeed()))
+					Expect(transitions).To(Equal([]transition{
+						{boost.CircuitClosed, boost.CircuitOpen},
+						{boost.CircuitOpen, boost.CircuitHalfOpen},
+						{boost.CircuitHalfOpen, boost.CircuitClosed},
+					}))
+				})
+			})
+		})
+
+		Context("RateLimit", func() {
+			parity := func(input int) string {
+				if input%2 == 0 {
+					return "even"
+				}
+
+				return "odd"
+			}
+
+			create := func(ctx context.Context, wg *sync.WaitGroup,
+				limit boost.RateLimit,
+			) *boost.ManifoldFuncPool[int, int] {
+				pool, err := boost.NewManifoldFuncPool(
+					ctx, func(input int) (int, error) {
+						return input, nil
+					}, wg,
+					boost.WithSize(PoolSize),
+					boost.WithRateLimit(limit),
+					boost.WithKeyFunc(boost.KeyFunc[int](parity)),
+				)
+				Expect(err).To(Succeed())
+
+				return pool
+			}
+
+			When("blocking", func() {
+				It("🧪 should: wait for token", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool := create(ctx, &wg, boost.RateLimit{
+						Rate:  100,
+						Burst: 1,
+					})
+					defer pool.Release(ctx)
+
+					start := time.Now()
+					for i := 0; i < 6; i++ {
+						Expect(pool.Post(ctx, i)).To(Succeed())
+					}
+
+					Expect(time.Since(start)).To(BeNumerically(">=", time.Millisecond*40))
+				})
+
+				It("🧪 should: abandon wait when context cancelled", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool := create(ctx, &wg, boost.RateLimit{
+						Rate:  0.1,
+						Burst: 1,
+					})
+					defer pool.Release(ctx)
+
+					Expect(pool.Post(ctx, 1)).To(Succeed())
+
+					pctx, pcancel := context.WithTimeout(ctx, time.Millisecond*20)
+					defer pcancel()
+					Expect(pool.Post(pctx, 2)).To(MatchError(context.DeadlineExceeded))
+				})
+			})
+
+			When("non-blocking", func() {
+				It("🧪 should: return rate limit error", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool := create(ctx, &wg, boost.RateLimit{
+						Rate:        1,
+						Burst:       2,
+						NonBlocking: true,
+					})
+					defer pool.Release(ctx)
+
+					Expect(pool.Post(ctx, 1)).To(Succeed())
+					Expect(pool.Post(ctx, 2)).To(Succeed())
+
+					err := pool.Post(ctx, 3)
+					Expect(err).To(MatchError(boost.ErrRateLimited))
+
+					var rle *boost.RateLimitError
+					Expect(errors.As(err, &rle)).To(BeTrue())
+					Expect(rle.RetryAfter).To(BeNumerically(">", 0))
+				})
+			})
+
+			When("per-key", func() {
+				It("🧪 should: throttle each key separately", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool := create(ctx, &wg, boost.RateLimit{
+						KeyRate:     1,
+						KeyBurst:    1,
+						NonBlocking: true,
+					})
+					defer pool.Release(ctx)
+
+					Expect(pool.Post(ctx, 1)).To(Succeed())
+					Expect(pool.Post(ctx, 2)).To(Succeed())
+
+					var rle *boost.RateLimitError
+					Expect(errors.As(pool.Post(ctx, 3), &rle)).To(BeTrue())
+					Expect(rle.Key).To(Equal("odd"))
+				})
+			})
+
+			When("keys are idle", func() {
+				It("🧪 should: evict their buckets", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldFuncPool(
+						ctx, func(input int) (int, error) {
+							return input, nil
+						}, &wg,
+						boost.WithSize(PoolSize),
+						boost.WithRateLimit(boost.RateLimit{
+							KeyRate:  1000,
+							KeyBurst: 1,
+						}),
+						boost.WithKeyFunc(boost.KeyFunc[int](strconv.Itoa)),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					const keys = 100
+
+					for batch := 0; batch < 3; batch++ {
+						for i := 0; i < keys; i++ {
+							Expect(pool.Post(ctx, batch*keys+i)).To(Succeed())
+						}
+
+						// allow the buckets to be refilled, so that they are idle
+						time.Sleep(time.Millisecond * 10)
+					}
+
+					Expect(pool.RateLimitKeys()).To(BeNumerically("<=", keys*2))
+				})
+			})
+		})
+
+		Context("Stats", func() {
+			It("🧪 should: count job outcomes", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				const (
+					panics  = 7
+					overrun = 8
+					fails   = 9
+				)
+
+				pool, err := boost.NewManifoldFuncPoolCtx(
+					ctx, func(jctx context.Context, input int) (int, error) {
+						switch input {
+						case panics:
+							panic("boom")
+						case overrun:
+							<-jctx.Done()
+						case fails:
+							return 0, errOddInput
+						}
+
+						return input, nil
+					}, &wg,
+					boost.WithSize(PoolSize),
+					boost.WithRecoverPanics(true),
+					boost.WithJobTimeout(time.Millisecond*20),
+					boost.WithOutput(10, 0, TimeoutOnSend),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				for i := 0; i < 10; i++ {
+					Expect(pool.Post(ctx, i)).To(Succeed())
+				}
+				pool.Conclude(ctx)
+
+				for range pool.Observe() {
+				}
+
+				stats := pool.Stats()
+				Expect(stats.Submitted).To(BeEquivalentTo(10))
+				Expect(stats.Completed).To(BeEquivalentTo(7))
+				Expect(stats.Failed).To(BeEquivalentTo(3))
+				Expect(stats.Panicked).To(BeEquivalentTo(1))
+				Expect(stats.TimedOut).To(BeEquivalentTo(1))
+				Expect(stats.SendTimeouts).To(BeZero())
+				Expect(stats.QueueWait.Count).To(BeEquivalentTo(10))
+				Expect(stats.Execution.Count).To(BeEquivalentTo(10))
+				Expect(stats.Execution.Sum).To(BeNumerically(">=", time.Millisecond*20))
+				Expect(stats.Spawned).NotTo(BeZero())
+			})
+		})
+
+		Context("Pause", func() {
+			When("paused", func() {
+				It("🧪 should: not start new jobs until resumed", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldFuncPool(
+						ctx, func(input int) (int, error) {
+							return input, nil
+						}, &wg,
+						boost.WithSize(2),
+						boost.WithOutput(10, 0, TimeoutOnSend),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					pool.Pause()
+					Expect(pool.IsPaused()).To(BeTrue())
+
+					for i := 1; i <= 2; i++ {
+						go func(input int) {
+							defer GinkgoRecover()
+
+							Expect(pool.Post(ctx, input)).To(Succeed())
+						}(i)
+						Eventually(pool.Waiting).Should(Equal(i))
+					}
+					Consistently(pool.Running, time.Millisecond*50).Should(BeZero())
+
+					pool.Conclude(ctx)
+					Consistently(pool.Observe(), time.Millisecond*50).ShouldNot(BeClosed())
+
+					pool.Resume()
+					Expect(pool.IsPaused()).To(BeFalse())
+
+					var payloads []int
+					for output := range pool.Observe() {
+						payloads = append(payloads, output.Payload)
+					}
+					Expect(payloads).To(ConsistOf(1, 2))
+				})
+			})
+
+			When("paused and non-blocking", func() {
+				It("🧪 should: reject post", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldFuncPool(
+						ctx, func(input int) (int, error) {
+							return input, nil
+						}, &wg,
+						boost.WithSize(2),
+						boost.WithNonblocking(true),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					pool.Pause()
+					Expect(pool.Post(ctx, 1)).To(MatchError(ants.ErrPoolOverload))
+
+					pool.Resume()
+					Expect(pool.Post(ctx, 2)).To(Succeed())
+				})
+			})
+		})
+
+		Context("Deduplication", func() {
+			When("jobs with the same key run concurrently", func() {
+				It("🧪 should: share output of single invocation", func(specCtx SpecContext) {
+					var (
+						wg      sync.WaitGroup
+						calls   int32
+						release = make(chan struct{})
+					)
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldFuncPool(
+						ctx, func(input string) (string, error) {
+							atomic.AddInt32(&calls, 1)
+							<-release
+
+							return input + "!", nil
+						}, &wg,
+						boost.WithSize(3),
+						boost.WithOutput(10, 0, TimeoutOnSend),
+						boost.WithDeduplication(func(input string) string {
+							return input
+						}),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					for _, input := range []string{"a", "a", "b"} {
+						Expect(pool.Post(ctx, input)).To(Succeed())
+					}
+					Eventually(pool.Running).Should(Equal(3))
+					Eventually(func() int32 {
+						return atomic.LoadInt32(&calls)
+					}).Should(BeEquivalentTo(2))
+					// allow the duplicate to join the executing job
+					time.Sleep(time.Millisecond * 20)
+					close(release)
+					pool.Conclude(ctx)
+
+					payloads := map[int]string{}
+					ids := map[string]bool{}
+					for output := range pool.Observe() {
+						Expect(output.Error).To(Succeed())
+						payloads[output.SequenceNo] = output.Payload
+						ids[output.ID] = true
+					}
+					Expect(payloads).To(Equal(map[int]string{1: "a!", 2: "a!", 3: "b!"}))
+					Expect(ids).To(HaveLen(3))
+					Expect(atomic.LoadInt32(&calls)).To(BeEquivalentTo(2))
+				})
+			})
+		})
+
+		Context("Capacity", func() {
+			When("heavy job is in flight", func() {
+				It("🧪 should: block post until weight is free", func(specCtx SpecContext) {
+					var (
+						wg      sync.WaitGroup
+						release = make(chan struct{})
+						posted  = make(chan struct{})
+					)
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldFuncPool(
+						ctx, func(input int) (int, error) {
+							if input == 1 {
+								<-release
+							}
+
+							return input, nil
+						}, &wg,
+						boost.WithSize(4),
+						boost.WithCapacity(4),
+						boost.WithOutput(10, 0, TimeoutOnSend),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					Expect(pool.PostJob(ctx, boost.Job[int]{Input: 1, Weight: 3})).To(Succeed())
+					Eventually(pool.InUseWeight).Should(BeEquivalentTo(3))
+
+					go func() {
+						defer GinkgoRecover()
+						defer close(posted)
+
+						Expect(pool.PostJob(ctx, boost.Job[int]{Input: 2, Weight: 2})).To(Succeed())
+					}()
+					Consistently(posted, time.Millisecond*50).ShouldNot(BeClosed())
+					Expect(pool.Stats().InUseWeight).To(BeEquivalentTo(3))
+
+					close(release)
+					Eventually(posted).Should(BeClosed())
+					pool.Conclude(ctx)
+
+					var payloads []int
+					for output := range pool.Observe() {
+						payloads = append(payloads, output.Payload)
+					}
+					Expect(payloads).To(ConsistOf(1, 2))
+					Expect(pool.InUseWeight()).To(BeZero())
+				})
+			})
+
+			When("weight exceeds capacity", func() {
+				It("🧪 should: reject post", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldFuncPool(
+						ctx, func(input int) (int, error) {
+							return input, nil
+						}, &wg,
+						boost.WithCapacity(2),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					Expect(pool.PostJob(ctx, boost.Job[int]{Input: 1, Weight: 3})).To(
+						MatchError(boost.ErrOverweight),
+					)
+				})
+			})
+		})
+
+		Context("Ordered", func() {
+			When("jobs complete out of order", func() {
+				It("🧪 should: release outputs by sequence number", func(specCtx SpecContext) {
+					var (
+						wg        sync.WaitGroup
+						sequences []int
+						payloads  []int
+					)
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					const n = 20
+
+					pool, err := boost.NewManifoldFuncPool(
+						ctx, func(input int) (int, error) {
+							time.Sleep(time.Millisecond * time.Duration(n-input))
+
+							return input, nil
+						}, &wg,
+						boost.WithSize(PoolSize),
+						boost.WithOrderedOutput(5),
+						boost.WithOutput(10, 0, TimeoutOnSend),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+
+						for i := 0; i < n; i++ {
+							Expect(pool.Post(ctx, i)).To(Succeed())
+						}
+						pool.Conclude(ctx)
+					}()
+
+					for output := range pool.Observe() {
+						sequences = append(sequences, output.SequenceNo)
+						payloads = append(payloads, output.Payload)
+					}
+					wg.Wait()
+
+					Expect(sequences).To(HaveLen(n))
+					for i := range sequences {
+						Expect(sequences[i]).To(Equal(i + 1))
+						Expect(payloads[i]).To(Equal(i))
+					}
+				})
+			})
+
+			When("reorder buffer is at its bound", func() {
+				It("🧪 should: block post", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					release := make(chan struct{})
+					pool, err := boost.NewManifoldFuncPool(
+						ctx, func(input int) (int, error) {
+							<-release
+
+							return input, nil
+						}, &wg,
+						boost.WithSize(PoolSize),
+						boost.WithOrderedOutput(2),
+						boost.WithOutput(10, 0, TimeoutOnSend),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					Expect(pool.Post(ctx, 1)).To(Succeed())
+					Expect(pool.Post(ctx, 2)).To(Succeed())
+
+					pctx, pcancel := context.WithTimeout(ctx, time.Millisecond*50)
+					defer pcancel()
+					Expect(pool.Post(pctx, 3)).To(MatchError(context.DeadlineExceeded))
+
+					close(release)
+					Expect(pool.Post(ctx, 3)).To(Succeed())
+					pool.Conclude(ctx)
+
+					var payloads []int
+					for output := range pool.Observe() {
+						payloads = append(payloads, output.Payload)
+					}
+					wg.Wait()
+
+					Expect(payloads).To(Equal([]int{1, 2, 3}))
+				})
+			})
+
+			When("a job panics without being recovered", func() {
+				It("🧪 should: release the outputs that follow", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldFuncPool(
+						ctx, func(input int) (int, error) {
+							if input == 0 {
+								panic("boom")
+							}
+
+							return input, nil
+						}, &wg,
+						boost.WithSize(PoolSize),
+						boost.WithOrderedOutput(3),
+						boost.WithOutput(10, 0, TimeoutOnSend),
+						boost.WithPanicHandler(func(interface{}) {}),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					pctx, pcancel := context.WithTimeout(ctx, time.Second)
+					defer pcancel()
+
+					for i := 0; i < 6; i++ {
+						Expect(pool.Post(pctx, i)).To(Succeed())
+					}
+					pool.Conclude(ctx)
+
+					var payloads []int
+					for output := range pool.Observe() {
+						payloads = append(payloads, output.Payload)
+					}
+					wg.Wait()
+
+					Expect(payloads).To(Equal([]int{1, 2, 3, 4, 5}))
+				})
+			})
+		})
+
 		Context("IfOption", func() {
 			When("true", func() {
 				It("🧪 should: use option", func(specCtx SpecContext) {
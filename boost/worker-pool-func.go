@@ -2,6 +2,7 @@ package boost
 
 import (
 	"context"
+	"time"
 
 	"github.com/snivilised/lorax/internal/ants"
 )
@@ -24,14 +25,63 @@ func NewFuncPool[I, O any](ctx context.Context,
 	// allocated for each job, but this is not necessarily
 	// the case, because each worker has its own job queue.
 	//
-	pool, err := ants.NewPoolWithFunc(ctx, pf, options...)
+	o := ants.NewOptions(options...)
+	if err := checkOptions[I, O](o,
+		withoutCapacity, withoutCache, withoutMiddleware, withoutJournal, withoutPriority,
+		withoutDeduplication,
+	); err != nil {
+		return nil, err
+	}
 
-	return &FuncPool[I, O]{
+	p := &FuncPool[I, O]{
 		basePool: basePool[I, O]{
-			wg: wg,
+			wg:      wg,
+			limiter: newLimiter(o),
 		},
-		functionalPool: functionalPool{
-			pool: pool,
-		},
-	}, err
+	}
+
+	pool, err := ants.NewPoolWithFunc(ctx, func(input InputParam) {
+		_ = p.stats.measure(time.Time{}, func() error {
+			pf(input)
+
+			return nil
+		})
+	}, options...)
+
+	p.functionalPool = functionalPool{
+		pool: pool,
+	}
+	p.workers = pool
+
+	return p, err
+}
+
+// Post submits a job to the pool.
+func (p *FuncPool[I, O]) Post(ctx context.Context, job InputParam) error {
+	if err := p.accepting(); err != nil {
+		return err
+	}
+
+	if err := p.throttle(ctx, ""); err != nil {
+		return err
+	}
+
+	return p.submit(func() error {
+		return p.pool.Invoke(ctx, job)
+	})
+}
+
+// Shutdown stops the pool from accepting any more jobs and waits up to
+// the grace period for in-flight jobs to complete. Since the inputs of this pool
+// are opaque, unstarted jobs are not tracked, so no jobs are returned.
+func (p *FuncPool[I, O]) Shutdown(ctx context.Context,
+	grace time.Duration,
+) ([]Job[I], error) {
+	return p.shutdown(ctx, grace, p.pool.ReleaseTimeout, nil)
+}
+
+// Stats returns a snapshot of the statistics of the pool. The queue
+// wait of jobs is not recorded, since the input is opaque.
+func (p *FuncPool[I, O]) Stats() PoolStats {
+	return p.stats.snapshot(p.pool)
 }
@@ -50,6 +50,7 @@ type WorkerPoolL[I, O any] struct {
 	private         privateWpInfoL[I, O]
 	outputChTimeout time.Duration
 	exec            ExecutiveFunc[I, O]
+	middleware      []Middleware[I, O]
 	noWorkers       int
 	sourceJobsChIn  JobStream[I]
 	RoutineName     GoRoutineName
@@ -64,6 +65,7 @@ type NewWorkerPoolParamsL[I, O any] struct {
 	NoWorkers       int
 	OutputChTimeout time.Duration
 	Exec            ExecutiveFunc[I, O]
+	Middleware      []Middleware[I, O]
 	JobsCh          JobStream[I]
 	CancelCh        CancelStream
 	WaitAQ          AnnotatedWgAQ
@@ -101,6 +103,7 @@ func NewWorkerPoolL[I, O any](params *NewWorkerPoolParamsL[I, O]) *WorkerPoolL[I
 		},
 		outputChTimeout: params.OutputChTimeout,
 		exec:            params.Exec,
+		middleware:      params.Middleware,
 		RoutineName:     GoRoutineName("🧊 worker pool"),
 		noWorkers:       noWorkers,
 		sourceJobsChIn:  params.JobsCh,
@@ -246,7 +249,7 @@ func (p *WorkerPoolL[I, O]) spawn(
 	w := &workerWrapperL[I, O]{
 		core: &workerL[I, O]{
 			id:            p.composeID(),
-			exec:          p.exec,
+			exec:          p.exec.intercept(parentContext, p.middleware),
 			jobsChIn:      jobsChIn,
 			outputsChOut:  outputsChOut,
 			finishedChOut: finishedChOut,
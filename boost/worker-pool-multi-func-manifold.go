@@ -0,0 +1,166 @@
+package boost
+
+import (
+	"context"
+	"time"
+
+	"github.com/snivilised/lorax/internal/ants"
+)
+
+// MultiManifoldFuncPool is the sharded equivalent of ManifoldFuncPool.
+// Jobs are distributed across multiple underlying ants function based
+// pools according to the load-balancing strategy, reducing the lock
+// contention that occurs when a single pool is overwhelmed by a large
+// number of jobs. Outputs from all pools are merged onto a single
+// output stream.
+type MultiManifoldFuncPool[I, O any] struct {
+	basePool[I, O]
+	pool    *ants.MultiPoolWithFunc
+	keyFunc KeyFunc[I]
+}
+
+// NewMultiManifoldFuncPool creates a new sharded manifold function based
+// worker pool, consisting of count pools, each of which is of the size
+// defined by the WithSize option. The KeyHash strategy requires a key
+// function to be defined via the WithKeyFunc option, otherwise jobs are
+// distributed in rotation.
+func NewMultiManifoldFuncPool[I, O any](ctx context.Context,
+	mf ManifoldFunc[I, O],
+	wg WaitGroup,
+	count int,
+	lbs LoadBalancingStrategy,
+	options ...Option,
+) (*MultiManifoldFuncPool[I, O], error) {
+	o := ants.NewOptions(options...)
+	if err := checkOptions[I, O](o,
+		withoutCapacity, withoutCache, withoutJournal, withoutPriority,
+	); err != nil {
+		return nil, err
+	}
+
+	p := &MultiManifoldFuncPool[I, O]{
+		basePool: basePool[I, O]{
+			wg:      wg,
+			limiter: newLimiter(o),
+		},
+		keyFunc: keyFuncFrom[I](o),
+	}
+
+	exec, fn := newExecutor[I](o, p.outputs(ctx, o), &p.stats), withContext(mf)
+	pool, err := ants.NewMultiPoolWithFunc(ctx, count, func(input InputParam) {
+		defer p.leave()
+
+		p.invoke(ctx, exec, fn, input)
+	}, lbs, ants.WithOptions(*o))
+
+	p.pool = pool
+	p.workers = pool
+
+	return p, err
+}
+
+// Post allows the client to submit to the work pool represented by
+// input values of type I.
+func (p *MultiManifoldFuncPool[I, O]) Post(ctx context.Context, input I) error {
+	if err := p.accepting(); err != nil {
+		return err
+	}
+
+	if err := p.throttle(ctx, keyOf(p.keyFunc, input)); err != nil {
+		return err
+	}
+
+	if err := p.admit(ctx); err != nil {
+		return err
+	}
+
+	o := p.pool.GetOptions()
+	job := Job[I]{
+		ID:         o.Generator.Generate(),
+		Input:      input,
+		SequenceNo: int(p.next()),
+		posted:     time.Now(),
+	}
+
+	return p.dispatch(job, func() error {
+		if p.keyFunc != nil {
+			return p.pool.InvokeWithKey(ctx, p.keyFunc(input), job)
+		}
+
+		return p.pool.Invoke(ctx, job)
+	})
+}
+
+// Source returns an input stream through which the client can submit
+// jobs to the pool. Using an input stream vs invoking Post is
+// mutually exclusive; that is to say, if Source is called, then Post
+// must not be called; any such invocations will be ignored.
+func (p *MultiManifoldFuncPool[I, O]) Source(ctx context.Context,
+	wg WaitGroup,
+) SourceStreamW[I] {
+	o := p.pool.GetOptions()
+
+	p.basePool.inputDupCh = source(ctx, wg, o,
+		injector[I](func(input I) error {
+			return p.Post(ctx, input)
+		}),
+		terminator(func() {
+			p.Conclude(ctx)
+		}),
+	)
+
+	return p.basePool.inputDupCh.WriterCh
+}
+
+// Conclude signifies to the worker pool that no more work will be
+// submitted. The same rules apply as for ManifoldFuncPool.Conclude.
+func (p *MultiManifoldFuncPool[I, O]) Conclude(ctx context.Context) {
+	if p.oi != nil {
+		p.conclude(ctx, p.closeOutput)
+	}
+}
+
+// Release closes all pools and releases their worker queues.
+func (p *MultiManifoldFuncPool[I, O]) Release(ctx context.Context) {
+	p.pool.Release(ctx)
+}
+
+// Running returns the number of workers currently running across
+// all pools.
+func (p *MultiManifoldFuncPool[I, O]) Running() int {
+	return p.pool.Running()
+}
+
+// Waiting returns the number of tasks waiting to be executed across
+// all pools.
+func (p *MultiManifoldFuncPool[I, O]) Waiting() int {
+	return p.pool.Waiting()
+}
+
+// Free returns the number of available workers across all pools.
+func (p *MultiManifoldFuncPool[I, O]) Free() int {
+	return p.pool.Free()
+}
+
+// Cap returns the combined capacity of all pools.
+func (p *MultiManifoldFuncPool[I, O]) Cap() int {
+	return p.pool.Cap()
+}
+
+func (p *MultiManifoldFuncPool[I, O]) GetOptions() *Options {
+	return p.pool.GetOptions()
+}
+
+// Shutdown stops the pool from accepting any more jobs and waits up to
+// the grace period for in-flight jobs to complete. The same rules apply
+// as for ManifoldFuncPool.Shutdown.
+func (p *MultiManifoldFuncPool[I, O]) Shutdown(ctx context.Context,
+	grace time.Duration,
+) ([]Job[I], error) {
+	return p.shutdown(ctx, grace, p.pool.ReleaseTimeout, p.Conclude)
+}
+
+// Stats returns a snapshot of the statistics of the pool.
+func (p *MultiManifoldFuncPool[I, O]) Stats() PoolStats {
+	return p.stats.snapshot(p.pool)
+}
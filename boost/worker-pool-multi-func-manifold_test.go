@@ -0,0 +1,117 @@
+package boost_test
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // ginkgo ok
+	. "github.com/onsi/gomega"    //nolint:revive // gomega ok
+
+	"github.com/snivilised/lorax/boost"
+)
+
+var _ = Describe("WorkerPoolMultiFuncManifold", func() {
+	DescribeTable("load-balancing strategies",
+		func(specCtx SpecContext, lbs boost.LoadBalancingStrategy) {
+			var (
+				wg    sync.WaitGroup
+				count int
+			)
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			pool, err := boost.NewMultiManifoldFuncPool(
+				ctx, demoPoolManifoldFunc, &wg, 4, lbs,
+				boost.WithSize(PoolSize),
+				boost.WithInput(InputBufferSize),
+				boost.WithOutput(10, CheckCloseInterval, TimeoutOnSend),
+				boost.WithKeyFunc(func(input int) string {
+					return strconv.Itoa(input % 3)
+				}),
+			)
+			Expect(err).To(Succeed())
+			defer pool.Release(ctx)
+
+			Expect(pool.Cap()).To(Equal(4 * PoolSize))
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				ch := pool.Source(ctx, &wg)
+				for i := 0; i < 100; i++ {
+					ch <- i % 10
+				}
+				close(ch)
+			}()
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				for output := range pool.Observe() {
+					Expect(output.Error).To(Succeed())
+					count++
+				}
+			}()
+
+			wg.Wait()
+			Expect(count).To(Equal(100))
+		},
+		Entry(nil, boost.RoundRobin),
+		Entry(nil, boost.LeastTasks),
+		Entry(nil, boost.KeyHash),
+	)
+
+	When("key function does not match input type", func() {
+		It("🧪 should: return option type error", func(specCtx SpecContext) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			_, err := boost.NewMultiManifoldFuncPool(
+				ctx, demoPoolManifoldFunc, &wg, 4, boost.KeyHash,
+				boost.WithSize(PoolSize),
+				boost.WithKeyFunc(func(input string) string {
+					return input
+				}),
+			)
+			Expect(err).To(MatchError(boost.ErrOptionType))
+		})
+	})
+
+	When("capacity is defined", func() {
+		It("🧪 should: return option unsupported error", func(specCtx SpecContext) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			_, err := boost.NewMultiManifoldFuncPool(
+				ctx, demoPoolManifoldFunc, &wg, 4, boost.RoundRobin,
+				boost.WithSize(PoolSize),
+				boost.WithCapacity(4),
+			)
+			Expect(err).To(MatchError(boost.ErrOptionUnsupported))
+		})
+	})
+
+	When("priority is defined", func() {
+		It("🧪 should: return option unsupported error", func(specCtx SpecContext) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			_, err := boost.NewMultiManifoldFuncPool(
+				ctx, demoPoolManifoldFunc, &wg, 4, boost.RoundRobin,
+				boost.WithSize(PoolSize),
+				boost.WithPriority(0),
+			)
+			Expect(err).To(MatchError(boost.ErrOptionUnsupported))
+		})
+	})
+})
@@ -0,0 +1,123 @@
+package boost
+
+import (
+	"context"
+	"time"
+
+	"github.com/snivilised/lorax/internal/ants"
+)
+
+// MultiTaskPool is the sharded equivalent of TaskPool. Tasks are
+// distributed across multiple underlying ants task based pools
+// according to the load-balancing strategy.
+type MultiTaskPool[I, O any] struct {
+	basePool[I, O]
+	pool *ants.MultiPool
+}
+
+// NewMultiTaskPool creates a new sharded task based worker pool,
+// consisting of count pools, each of which is of the size defined by
+// the WithSize option.
+func NewMultiTaskPool[I, O any](ctx context.Context,
+	wg WaitGroup,
+	count int,
+	lbs LoadBalancingStrategy,
+	options ...Option,
+) (*MultiTaskPool[I, O], error) {
+	o := ants.NewOptions(options...)
+	if err := checkOptions[I, O](o,
+		withoutCapacity, withoutCache, withoutMiddleware, withoutJournal,
+		withoutPriority, withoutDeduplication,
+	); err != nil {
+		return nil, err
+	}
+
+	pool, err := ants.NewMultiPool(ctx, count, lbs, options...)
+
+	return &MultiTaskPool[I, O]{
+		basePool: basePool[I, O]{
+			wg:      wg,
+			limiter: newLimiter(o),
+			workers: pool,
+		},
+		pool: pool,
+	}, err
+}
+
+// Post submits a task to a pool selected by the load-balancing strategy.
+func (p *MultiTaskPool[I, O]) Post(ctx context.Context, task TaskFunc) error {
+	if err := p.accepting(); err != nil {
+		return err
+	}
+
+	if err := p.throttle(ctx, ""); err != nil {
+		return err
+	}
+
+	return p.submit(func() error {
+		return p.pool.Submit(ctx, p.track(task))
+	})
+}
+
+// PostWithKey submits a task to the pool selected by hashing the key,
+// when the load-balancing strategy is KeyHash. For any other strategy,
+// the key is ignored for the purposes of load balancing, but still
+// applies to a per-key rate limit.
+func (p *MultiTaskPool[I, O]) PostWithKey(ctx context.Context, key string, task TaskFunc) error {
+	if err := p.accepting(); err != nil {
+		return err
+	}
+
+	if err := p.throttle(ctx, key); err != nil {
+		return err
+	}
+
+	return p.submit(func() error {
+		return p.pool.SubmitWithKey(ctx, key, p.track(task))
+	})
+}
+
+// Release closes all pools and releases their worker queues.
+func (p *MultiTaskPool[I, O]) Release(ctx context.Context) {
+	p.pool.Release(ctx)
+}
+
+// Running returns the number of workers currently running across
+// all pools.
+func (p *MultiTaskPool[I, O]) Running() int {
+	return p.pool.Running()
+}
+
+// Waiting returns the number of tasks waiting to be executed across
+// all pools.
+func (p *MultiTaskPool[I, O]) Waiting() int {
+	return p.pool.Waiting()
+}
+
+// Free returns the number of available workers across all pools.
+func (p *MultiTaskPool[I, O]) Free() int {
+	return p.pool.Free()
+}
+
+// Cap returns the combined capacity of all pools.
+func (p *MultiTaskPool[I, O]) Cap() int {
+	return p.pool.Cap()
+}
+
+func (p *MultiTaskPool[I, O]) GetOptions() *Options {
+	return p.pool.GetOptions()
+}
+
+// Shutdown stops the pool from accepting any more tasks and waits up to
+// the grace period for in-flight tasks to complete. Since the tasks of this pool
+// are opaque, unstarted tasks are not tracked, so no jobs are returned.
+func (p *MultiTaskPool[I, O]) Shutdown(ctx context.Context,
+	grace time.Duration,
+) ([]Job[I], error) {
+	return p.shutdown(ctx, grace, p.pool.ReleaseTimeout, nil)
+}
+
+// Stats returns a snapshot of the statistics aggregated across all pools.
+func (p *MultiTaskPool[I, O]) Stats() PoolStats {
+	return p.stats.snapshot(p.pool)
+}
@@ -0,0 +1,60 @@
+package boost_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // ginkgo ok
+	. "github.com/onsi/gomega"    //nolint:revive // gomega ok
+
+	"github.com/snivilised/lorax/boost"
+	"github.com/snivilised/lorax/internal/ants"
+)
+
+var _ = Describe("WorkerPoolMultiTask", func() {
+	When("invalid strategy", func() {
+		It("🧪 should: return error", func(specCtx SpecContext) {
+			var wg sync.WaitGroup
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			_, err := boost.NewMultiTaskPool[int, int](ctx, &wg, 2,
+				boost.LoadBalancingStrategy(0),
+			)
+			Expect(err).To(MatchError(ants.ErrInvalidLoadBalancingStrategy))
+		})
+	})
+
+	When("posting with key", func() {
+		It("🧪 should: execute all tasks", func(specCtx SpecContext) {
+			var (
+				wg       sync.WaitGroup
+				executed int32
+			)
+
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			pool, err := boost.NewMultiTaskPool[int, int](ctx, &wg, 3, boost.KeyHash,
+				boost.WithSize(PoolSize),
+			)
+			Expect(err).To(Succeed())
+			defer pool.Release(ctx)
+
+			keys := []string{"alpha", "beta", "gamma"}
+			for i := 0; i < 30; i++ {
+				wg.Add(1)
+				Expect(pool.PostWithKey(ctx, keys[i%3], func() {
+					defer wg.Done()
+					atomic.AddInt32(&executed, 1)
+				})).To(Succeed())
+			}
+
+			wg.Wait()
+			Expect(atomic.LoadInt32(&executed)).To(BeEquivalentTo(30))
+			Expect(pool.Free()).To(BeNumerically("<=", pool.Cap()))
+		})
+	})
+})
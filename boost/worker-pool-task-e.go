@@ -0,0 +1,137 @@
+package boost
+
+import (
+	"context"
+	"time"
+
+	"github.com/snivilised/lorax/internal/ants"
+)
+
+type (
+	// TaskE bundles the input of a job together with the error returning
+	// function that processes it, so that each job submitted to a
+	// TaskPoolE can be executed by a different function.
+	TaskE[I any] struct {
+		Input I
+		Func  FuncE[I]
+	}
+)
+
+// TaskPoolE is a task based worker pool whose jobs emit only an error.
+// Any error returned by a task is aggregated into the PoolResult which
+// is delivered on the completion stream once the pool has finished.
+type TaskPoolE[I any] struct {
+	basePool[TaskE[I], any]
+	taskPool
+	completion
+	retrier    *retrier
+	middleware []Middleware[I, any]
+}
+
+// NewTaskPoolE creates a new error returning task based worker pool.
+func NewTaskPoolE[I any](ctx context.Context,
+	wg WaitGroup,
+	options ...Option,
+) (*TaskPoolE[I], error) {
+	o := ants.NewOptions(options...)
+	if err := checkOptions[I, any](o,
+		withoutCapacity, withoutCache, withoutJournal, withoutPriority,
+		withoutDeduplication,
+	); err != nil {
+		return nil, err
+	}
+
+	pool, err := ants.NewPool(ctx, ants.WithOptions(*o))
+
+	return &TaskPoolE[I]{
+		basePool: basePool[TaskE[I], any]{
+			wg:      wg,
+			limiter: newLimiter(o),
+			workers: pool,
+		},
+		taskPool: taskPool{
+			pool: pool,
+		},
+		completion: newCompletion(),
+		retrier:    newRetrier(o),
+		middleware: middlewareFrom[I, any](o),
+	}, err
+}
+
+// Post allows the client to submit to the work pool a task, which
+// consists of an input value of type I and the function that processes
+// it.
+func (p *TaskPoolE[I]) Post(ctx context.Context, task TaskE[I]) error {
+	if err := p.accepting(); err != nil {
+		return err
+	}
+
+	if err := p.throttle(ctx, ""); err != nil {
+		return err
+	}
+
+	o := p.pool.GetOptions()
+	job := Job[I]{
+		ID:         o.Generator.Generate(),
+		Input:      task.Input,
+		SequenceNo: int(p.next()),
+		posted:     time.Now(),
+	}
+
+	return p.dispatch(withInput(job, task), func() error {
+		return p.pool.Submit(ctx, func() {
+			defer p.leave()
+
+			p.started(job.SequenceNo)
+
+			err := p.stats.measure(job.posted, func() error {
+				return executeE(ctx, p.middleware, p.retrier, task.Func, job)
+			})
+			p.report(job.ID, job.SequenceNo, err)
+		})
+	})
+}
+
+// Source returns an input stream through which the client can submit
+// tasks to the pool. Using an input stream vs invoking Post is
+// mutually exclusive; that is to say, if Source is called, then Post
+// must not be called; any such invocations will be ignored.
+func (p *TaskPoolE[I]) Source(ctx context.Context,
+	wg WaitGroup,
+) SourceStreamW[TaskE[I]] {
+	o := p.pool.GetOptions()
+
+	p.basePool.inputDupCh = source(ctx, wg, o,
+		injector[TaskE[I]](func(task TaskE[I]) error {
+			return p.Post(ctx, task)
+		}),
+		terminator(func() {
+			p.Conclude(ctx)
+		}),
+	)
+
+	return p.basePool.inputDupCh.WriterCh
+}
+
+// Conclude signifies to the worker pool that no more work will be
+// submitted. Once all outstanding tasks have completed, the PoolResult
+// is sent on the completion stream. When using Post, the client must
+// call Conclude, but when using Source, Conclude is invoked
+// automatically once the input channel has been closed.
+func (p *TaskPoolE[I]) Conclude(ctx context.Context) {
+	p.conclude(ctx, p.complete)
+}
+
+// Shutdown stops the pool from accepting any more tasks and waits up to
+// the grace period for in-flight tasks to complete, returning the jobs
+// that were never started, each of which carries its task.
+func (p *TaskPoolE[I]) Shutdown(ctx context.Context,
+	grace time.Duration,
+) ([]Job[TaskE[I]], error) {
+	return p.shutdown(ctx, grace, p.pool.ReleaseTimeout, p.Conclude)
+}
+
+// Stats returns a snapshot of the statistics of the pool.
+func (p *TaskPoolE[I]) Stats() PoolStats {
+	return p.stats.snapshot(p.pool)
+}
@@ -0,0 +1,198 @@
+package boost_test
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // ginkgo ok
+	. "github.com/onsi/gomega"    //nolint:revive // gomega ok
+
+	"github.com/snivilised/lorax/boost"
+)
+
+var _ = Describe("WorkerPoolTaskE", func() {
+	Context("ants", func() {
+		When("some tasks fail", func() {
+			It("🧪 should: identify the first failure", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewTaskPoolE[int](ctx, &wg,
+					boost.WithSize(1),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				for i := 0; i < 10; i++ {
+					Expect(pool.Post(ctx, boost.TaskE[int]{
+						Input: i,
+						Func:  failOdd,
+					})).To(Succeed())
+				}
+				pool.Conclude(ctx)
+
+				result := <-pool.Completion()
+				wg.Wait()
+
+				Expect(result.Error).To(MatchError(errOddInput))
+				Expect(result.FirstFailure).NotTo(BeNil())
+				Expect(result.FirstFailure.SequenceNo).To(Equal(2))
+			})
+		})
+
+		When("a task panics", func() {
+			It("🧪 should: report panic as first failure", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewTaskPoolE[int](ctx, &wg,
+					boost.WithSize(1),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				for i := 0; i < 3; i++ {
+					Expect(pool.Post(ctx, boost.TaskE[int]{
+						Input: i,
+						Func: func(input int) error {
+							if input == 1 {
+								panic("boom")
+							}
+
+							return nil
+						},
+					})).To(Succeed())
+				}
+				pool.Conclude(ctx)
+
+				result := <-pool.Completion()
+				wg.Wait()
+
+				Expect(result.Error).To(MatchError(boost.ErrJobPanic))
+				Expect(result.FirstFailure).NotTo(BeNil())
+				Expect(result.FirstFailure.SequenceNo).To(Equal(2))
+			})
+		})
+
+		When("retry policy defined", func() {
+			It("🧪 should: succeed after retrying", func(specCtx SpecContext) {
+				var (
+					wg    sync.WaitGroup
+					calls int32
+				)
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewTaskPoolE[int](ctx, &wg,
+					boost.WithSize(PoolSize),
+					boost.WithRetry(boost.RetryPolicy{
+						MaxAttempts: 3,
+					}),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				Expect(pool.Post(ctx, boost.TaskE[int]{
+					Input: 1,
+					Func: func(input int) error {
+						if atomic.AddInt32(&calls, 1) < 3 {
+							return failOdd(input)
+						}
+
+						return nil
+					},
+				})).To(Succeed())
+				pool.Conclude(ctx)
+
+				result := <-pool.Completion()
+				wg.Wait()
+
+				Expect(result.Error).To(Succeed())
+				Expect(atomic.LoadInt32(&calls)).To(BeEquivalentTo(3))
+			})
+		})
+
+		When("with input stream", func() {
+			It("🧪 should: deliver successful pool result", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewTaskPoolE[int](ctx, &wg,
+					boost.WithSize(PoolSize),
+					boost.WithInput(InputBufferSize),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				ch := pool.Source(ctx, &wg)
+				for i := 0; i < 10; i++ {
+					ch <- boost.TaskE[int]{
+						Input: i * 2,
+						Func:  failOdd,
+					}
+				}
+				close(ch)
+
+				result := <-pool.Completion()
+				wg.Wait()
+
+				Expect(result.Error).To(Succeed())
+			})
+		})
+
+		When("shutdown whilst tasks are blocked", func() {
+			It("🧪 should: return unstarted tasks", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewTaskPoolE[int](ctx, &wg,
+					boost.WithSize(1),
+				)
+				Expect(err).To(Succeed())
+
+				release := make(chan struct{})
+				Expect(pool.Post(ctx, boost.TaskE[int]{
+					Input: 0,
+					Func: func(int) error {
+						<-release
+
+						return nil
+					},
+				})).To(Succeed())
+
+				errs := make(chan error, 1)
+				go func() {
+					errs <- pool.Post(ctx, boost.TaskE[int]{
+						Input: 1,
+						Func:  failOdd,
+					})
+				}()
+				Eventually(pool.Waiting).Should(Equal(1))
+
+				time.AfterFunc(time.Millisecond*20, func() {
+					close(release)
+				})
+				unstarted, err := pool.Shutdown(ctx, time.Second)
+				Expect(err).To(Succeed())
+				Expect(<-errs).To(MatchError(boost.ErrPoolClosed))
+				Expect(unstarted).To(HaveLen(1))
+				Expect(unstarted[0].Input.Input).To(Equal(1))
+				Expect(unstarted[0].Input.Func(1)).To(MatchError(errOddInput))
+
+				result := <-pool.Completion()
+				Expect(result.Error).To(Succeed())
+			})
+		})
+	})
+})
@@ -0,0 +1,146 @@
+package boost
+
+import (
+	"context"
+	"time"
+
+	"github.com/snivilised/lorax/internal/ants"
+)
+
+type (
+	// ManifoldTask bundles the input of a job together with the function
+	// that processes it, so that each job submitted to a ManifoldTaskPool
+	// can be executed by a different function.
+	ManifoldTask[I, O any] struct {
+		Input I
+		Func  ManifoldFunc[I, O]
+	}
+)
+
+// ManifoldTaskPool is a wrapper around the underlying ants task based
+// worker pool. Unlike the ManifoldFuncPool, there is no pre-defined
+// function registered with the pool; rather each job carries its own
+// function, allowing for heterogeneous jobs that still emit outputs of
+// type O. The client is expected to create an output channel to
+// receive the outputs of executing jobs in the worker pool. If the
+// output channel is not defined, then jobs will still be executed, but
+// the output of which will not be sent, also losing job execution error
+// status.
+type ManifoldTaskPool[I, O any] struct {
+	basePool[ManifoldTask[I, O], O]
+	taskPool
+	exec *executor[I, O]
+}
+
+// NewManifoldTaskPool creates a new manifold task based worker pool.
+func NewManifoldTaskPool[I, O any](ctx context.Context,
+	wg WaitGroup,
+	options ...Option,
+) (*ManifoldTaskPool[I, O], error) {
+	o := ants.NewOptions(options...)
+	if err := checkOptions[I, O](o,
+		withoutCapacity, withoutCache, withoutJournal, withoutPriority,
+		withoutDeduplication,
+	); err != nil {
+		return nil, err
+	}
+
+	p := &ManifoldTaskPool[I, O]{
+		basePool: basePool[ManifoldTask[I, O], O]{
+			wg:      wg,
+			limiter: newLimiter(o),
+		},
+	}
+
+	p.exec = newExecutor[I](o, p.outputs(ctx, o), &p.stats)
+	pool, err := ants.NewPool(ctx, ants.WithOptions(*o))
+	p.taskPool = taskPool{
+		pool: pool,
+	}
+	p.workers = pool
+
+	return p, err
+}
+
+// Post allows the client to submit to the work pool a task, which
+// consists of an input value of type I and the function that processes
+// it.
+func (p *ManifoldTaskPool[I, O]) Post(ctx context.Context, task ManifoldTask[I, O]) error {
+	if err := p.accepting(); err != nil {
+		return err
+	}
+
+	if err := p.throttle(ctx, ""); err != nil {
+		return err
+	}
+
+	if err := p.admit(ctx); err != nil {
+		return err
+	}
+
+	o := p.pool.GetOptions()
+	job := Job[I]{
+		ID:         o.Generator.Generate(),
+		Input:      task.Input,
+		SequenceNo: int(p.next()),
+		posted:     time.Now(),
+	}
+
+	return p.dispatch(withInput(job, task), func() error {
+		return p.pool.Submit(ctx, func() {
+			defer p.leave()
+
+			p.started(job.SequenceNo)
+			p.guard(job.SequenceNo, func() {
+				p.exec.run(ctx, withContext(task.Func), job)
+			})
+		})
+	})
+}
+
+// Source returns an input stream through which the client can submit
+// tasks to the pool. Using an input stream vs invoking Post is
+// mutually exclusive; that is to say, if Source is called, then Post
+// must not be called; any such invocations will be ignored.
+func (p *ManifoldTaskPool[I, O]) Source(ctx context.Context,
+	wg WaitGroup,
+) SourceStreamW[ManifoldTask[I, O]] {
+	o := p.pool.GetOptions()
+
+	p.basePool.inputDupCh = source(ctx, wg, o,
+		injector[ManifoldTask[I, O]](func(task ManifoldTask[I, O]) error {
+			return p.Post(ctx, task)
+		}),
+		terminator(func() {
+			p.Conclude(ctx)
+		}),
+	)
+
+	return p.basePool.inputDupCh.WriterCh
+}
+
+// Conclude signifies to the worker pool that no more work will be
+// submitted. The same rules apply as for ManifoldFuncPool.Conclude;
+// when using Post, the client must call Conclude, but when using
+// Source, Conclude is invoked automatically once the input channel
+// has been closed.
+func (p *ManifoldTaskPool[I, O]) Conclude(ctx context.Context) {
+	if p.oi != nil {
+		p.conclude(ctx, p.closeOutput)
+	}
+}
+
+// Shutdown stops the pool from accepting any more tasks and waits up to
+// the grace period for in-flight tasks to complete. The same rules apply
+// as for ManifoldFuncPool.Shutdown; each unstarted job returned carries
+// the task, so that it can be re-submitted.
+func (p *ManifoldTaskPool[I, O]) Shutdown(ctx context.Context,
+	grace time.Duration,
+) ([]Job[ManifoldTask[I, O]], error) {
+	return p.shutdown(ctx, grace, p.pool.ReleaseTimeout, p.Conclude)
+}
+
+// Stats returns a snapshot of the statistics of the pool.
+func (p *ManifoldTaskPool[I, O]) Stats() PoolStats {
+	return p.stats.snapshot(p.pool)
+}
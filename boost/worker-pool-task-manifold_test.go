@@ -0,0 +1,212 @@
+package boost_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // ginkgo ok
+	. "github.com/onsi/gomega"    //nolint:revive // gomega ok
+
+	"github.com/snivilised/lorax/boost"
+)
+
+var errOddInput = errors.New("odd input")
+
+func double(input int) (string, error) {
+	return strconv.Itoa(input * 2), nil
+}
+
+func rejectOdd(input int) (string, error) {
+	if input%2 == 1 {
+		return "", errOddInput
+	}
+
+	return strconv.Itoa(input), nil
+}
+
+func produceTasks(ctx context.Context,
+	pool *boost.ManifoldTaskPool[int, string],
+	wg boost.WaitGroup,
+) {
+	defer wg.Done()
+
+	for i, n := 0, 100; i < n; i++ {
+		_ = pool.Post(ctx, boost.ManifoldTask[int, string]{
+			Input: i,
+			Func:  double,
+		})
+	}
+
+	pool.Conclude(ctx)
+}
+
+func injectTasks(ctx context.Context,
+	pool *boost.ManifoldTaskPool[int, string],
+	wg boost.WaitGroup,
+) {
+	defer wg.Done()
+
+	ch := pool.Source(ctx, wg)
+	for i, n := 0, 100; i < n; i++ {
+		ch <- boost.ManifoldTask[int, string]{
+			Input: i,
+			Func:  rejectOdd,
+		}
+	}
+
+	close(ch)
+}
+
+var _ = Describe("WorkerPoolTaskManifold", func() {
+	Context("ants", func() {
+		When("NonBlocking", func() {
+			Context("with consumer", func() {
+				It("🧪 should: receive output for each task", func(specCtx SpecContext) {
+					var (
+						wg    sync.WaitGroup
+						count int
+					)
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldTaskPool[int, string](
+						ctx, &wg,
+						boost.WithSize(PoolSize),
+						boost.WithOutput(10, CheckCloseInterval, TimeoutOnSend),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					wg.Add(1)
+					go produceTasks(ctx, pool, &wg)
+
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+
+						for output := range pool.Observe() {
+							Expect(output.Error).To(Succeed())
+							Expect(output.ID).NotTo(BeEmpty())
+							Expect(output.SequenceNo).NotTo(Equal(0))
+							count++
+						}
+					}()
+
+					wg.Wait()
+					Expect(count).To(Equal(100))
+				})
+			})
+
+			Context("with input stream", func() {
+				It("🧪 should: report task errors", func(specCtx SpecContext) {
+					var (
+						wg     sync.WaitGroup
+						failed int
+					)
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldTaskPool[int, string](
+						ctx, &wg,
+						boost.WithSize(PoolSize),
+						boost.WithInput(InputBufferSize),
+						boost.WithOutput(10, CheckCloseInterval, TimeoutOnSend),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					wg.Add(1)
+					go injectTasks(ctx, pool, &wg)
+
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+
+						for output := range pool.Observe() {
+							if output.Error != nil {
+								Expect(output.Error).To(MatchError(errOddInput))
+								failed++
+							}
+						}
+					}()
+
+					wg.Wait()
+					Expect(failed).To(Equal(50))
+				})
+			})
+
+			Context("with ordered output", func() {
+				It("🧪 should: release outputs by sequence number", func(specCtx SpecContext) {
+					var (
+						wg   sync.WaitGroup
+						next = 1
+					)
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldTaskPool[int, string](
+						ctx, &wg,
+						boost.WithSize(PoolSize),
+						boost.WithOrderedOutput(10),
+						boost.WithOutput(10, CheckCloseInterval, TimeoutOnSend),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					wg.Add(1)
+					go produceTasks(ctx, pool, &wg)
+
+					for output := range pool.Observe() {
+						Expect(output.SequenceNo).To(Equal(next))
+						next++
+					}
+					wg.Wait()
+
+					Expect(next).To(Equal(101))
+				})
+			})
+
+			Context("without consumer", func() {
+				It("🧪 should: not fail", func(specCtx SpecContext) {
+					var wg sync.WaitGroup
+
+					ctx, cancel := context.WithCancel(specCtx)
+					defer cancel()
+
+					pool, err := boost.NewManifoldTaskPool[int, string](
+						ctx, &wg,
+						boost.WithSize(PoolSize),
+					)
+					Expect(err).To(Succeed())
+					defer pool.Release(ctx)
+
+					wg.Add(1)
+					go produceTasks(ctx, pool, &wg)
+
+					wg.Wait()
+					Expect(pool.CancelCh()).To(BeNil())
+				})
+			})
+		})
+
+		When("deduplication is defined", func() {
+			It("🧪 should: return option unsupported error", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				_, err := boost.NewManifoldTaskPool[int, string](ctx, &wg,
+					boost.WithSize(PoolSize),
+					boost.WithDeduplication(strconv.Itoa),
+				)
+				Expect(err).To(MatchError(boost.ErrOptionUnsupported))
+			})
+		})
+	})
+})
@@ -24,6 +24,7 @@ package boost
 //
 import (
 	"context"
+	"time"
 
 	"github.com/snivilised/lorax/internal/ants"
 )
@@ -40,14 +41,53 @@ func NewTaskPool[I, O any](ctx context.Context,
 	wg WaitGroup,
 	options ...Option,
 ) (*TaskPool[I, O], error) {
+	o := ants.NewOptions(options...)
+	if err := checkOptions[I, O](o,
+		withoutCapacity, withoutCache, withoutMiddleware, withoutJournal,
+		withoutPriority, withoutDeduplication,
+	); err != nil {
+		return nil, err
+	}
+
 	pool, err := ants.NewPool(ctx, options...)
 
 	return &TaskPool[I, O]{
 		basePool: basePool[I, O]{
-			wg: wg,
+			wg:      wg,
+			limiter: newLimiter(o),
+			workers: pool,
 		},
 		taskPool: taskPool{
 			pool: pool,
 		},
 	}, err
 }
+
+// Post submits a task to the pool.
+func (p *TaskPool[I, O]) Post(ctx context.Context, task TaskFunc) error {
+	if err := p.accepting(); err != nil {
+		return err
+	}
+
+	if err := p.throttle(ctx, ""); err != nil {
+		return err
+	}
+
+	return p.submit(func() error {
+		return p.pool.Submit(ctx, p.track(task))
+	})
+}
+
+// Shutdown stops the pool from accepting any more tasks and waits up to
+// the grace period for in-flight tasks to complete. Since the tasks of this pool
+// are opaque, unstarted tasks are not tracked, so no jobs are returned.
+func (p *TaskPool[I, O]) Shutdown(ctx context.Context,
+	grace time.Duration,
+) ([]Job[I], error) {
+	return p.shutdown(ctx, grace, p.pool.ReleaseTimeout, nil)
+}
+
+// Stats returns a snapshot of the statistics of the pool.
+func (p *TaskPool[I, O]) Stats() PoolStats {
+	return p.stats.snapshot(p.pool)
+}
@@ -120,5 +120,69 @@ var _ = Describe("WorkerPoolTask", func() {
 				}
 			})
 		})
+
+		When("Stats", func() {
+			It("🧪 should: count executed tasks", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewTaskPool[int, int](ctx, &wg,
+					boost.WithSize(PoolSize),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				for i := 0; i < 5; i++ {
+					wg.Add(1)
+					Expect(pool.Post(ctx, func() {
+						defer wg.Done()
+					})).To(Succeed())
+				}
+				wg.Wait()
+
+				Eventually(func() uint64 {
+					return pool.Stats().Completed
+				}).Should(BeEquivalentTo(5))
+
+				stats := pool.Stats()
+				Expect(stats.Submitted).To(BeEquivalentTo(5))
+				Expect(stats.Failed).To(BeZero())
+				Expect(stats.QueueWait.Count).To(BeEquivalentTo(5))
+				Expect(stats.Execution.Counts).To(HaveLen(len(stats.Execution.Bounds) + 1))
+				Expect(stats.Spawned).NotTo(BeZero())
+			})
+		})
+
+		When("capacity is defined", func() {
+			It("🧪 should: return option unsupported error", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				_, err := boost.NewTaskPool[int, int](ctx, &wg,
+					boost.WithSize(PoolSize),
+					boost.WithCapacity(4),
+				)
+				Expect(err).To(MatchError(boost.ErrOptionUnsupported))
+			})
+		})
+
+		When("priority is defined", func() {
+			It("🧪 should: return option unsupported error", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				_, err := boost.NewTaskPool[int, int](ctx, &wg,
+					boost.WithSize(PoolSize),
+					boost.WithPriority(0),
+				)
+				Expect(err).To(MatchError(boost.ErrOptionUnsupported))
+			})
+		})
 	})
 })
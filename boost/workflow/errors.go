@@ -0,0 +1,84 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrCycle can be used with errors.Is to determine whether a workflow
+	// was rejected because its tasks depend on each other in a cycle.
+	ErrCycle = errors.New("workflow contains a cycle")
+
+	// ErrDuplicateTask is returned when more than one task has the same ID.
+	ErrDuplicateTask = errors.New("duplicate task")
+
+	// ErrUnknownDependency is returned when a task depends on a task that
+	// is not part of the workflow.
+	ErrUnknownDependency = errors.New("unknown dependency")
+
+	// ErrInvalidTask is returned when a task has no ID or no function.
+	ErrInvalidTask = errors.New("invalid task")
+
+	// ErrSkipped can be used with errors.Is to determine whether a task
+	// was skipped.
+	ErrSkipped = errors.New("task skipped")
+)
+
+// CycleError identifies the tasks that form a cycle. The first task of
+// the path is repeated at the end.
+type CycleError struct {
+	Path []string
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("%v: '%v'", ErrCycle, strings.Join(e.Path, " -> "))
+}
+
+func (e *CycleError) Is(target error) bool {
+	return target == ErrCycle
+}
+
+// TaskError identifies the task that failed, along with the error that
+// it returned.
+type TaskError struct {
+	ID  string
+	Err error
+}
+
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("task '%v' failed: %v", e.ID, e.Err)
+}
+
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+// SkippedError is the error reported for a task that was not executed,
+// because a task it depends on, directly or indirectly, failed or
+// because the workflow was cancelled. Failed identifies the task that
+// failed, if any, and Err is the reason.
+type SkippedError struct {
+	ID     string
+	Failed string
+	Err    error
+}
+
+func (e *SkippedError) Error() string {
+	if e.Failed == "" {
+		return fmt.Sprintf("task '%v' skipped: %v", e.ID, e.Err)
+	}
+
+	return fmt.Sprintf("task '%v' skipped, dependency '%v' failed: %v",
+		e.ID, e.Failed, e.Err,
+	)
+}
+
+func (e *SkippedError) Is(target error) bool {
+	return target == ErrSkipped
+}
+
+func (e *SkippedError) Unwrap() error {
+	return e.Err
+}
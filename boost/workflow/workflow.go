@@ -0,0 +1,328 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+
+	"github.com/snivilised/lorax/boost"
+)
+
+type (
+	// TaskFunc is the function of a task, which receives the outputs of
+	// the tasks it depends on, keyed by their ID.
+	TaskFunc[O any] func(ctx context.Context, inputs map[string]O) (O, error)
+
+	// Task is a unit of work in a workflow, which can only be executed
+	// once all the tasks it depends on have succeeded.
+	Task[O any] struct {
+		ID        string
+		DependsOn []string
+		Func      TaskFunc[O]
+	}
+
+	// Result is the outcome of a task. The Err of a task that was not
+	// executed is a SkippedError.
+	Result[O any] struct {
+		Output O
+		Err    error
+	}
+
+	// Results contains the outcome of every task, keyed by ID.
+	Results[O any] map[string]Result[O]
+
+	// Workflow is a directed acyclic graph of tasks. Tasks whose
+	// dependencies have all succeeded are executed concurrently on a
+	// worker pool.
+	Workflow[O any] struct {
+		tasks      []Task[O]
+		index      map[string]int
+		dependents map[string][]string
+	}
+
+	// completion is sent by a worker when a task has been executed.
+	completion[O any] struct {
+		id     string
+		result Result[O]
+	}
+
+	// execution is the state of a single run of a workflow.
+	execution[O any] struct {
+		workflow  *Workflow[O]
+		pool      *boost.TaskPool[string, O]
+		results   Results[O]
+		remaining map[string]int
+		running   int
+		doneCh    chan completion[O]
+	}
+)
+
+// New creates a workflow from the tasks, which are validated to ensure
+// that every task has a unique ID, that every dependency is a task of
+// the workflow and that there are no cycles.
+func New[O any](tasks ...Task[O]) (*Workflow[O], error) {
+	w := &Workflow[O]{
+		tasks:      tasks,
+		index:      make(map[string]int, len(tasks)),
+		dependents: make(map[string][]string, len(tasks)),
+	}
+
+	for i, task := range tasks {
+		if task.ID == "" || task.Func == nil {
+			return nil, fmt.Errorf("%w (index: '%v')", ErrInvalidTask, i)
+		}
+
+		if _, found := w.index[task.ID]; found {
+			return nil, fmt.Errorf("%w: '%v'", ErrDuplicateTask, task.ID)
+		}
+
+		w.index[task.ID] = i
+	}
+
+	for _, task := range tasks {
+		for _, dependency := range task.DependsOn {
+			if _, found := w.index[dependency]; !found {
+				return nil, fmt.Errorf("%w: '%v' (task: '%v')",
+					ErrUnknownDependency, dependency, task.ID,
+				)
+			}
+
+			w.dependents[dependency] = append(w.dependents[dependency], task.ID)
+		}
+	}
+
+	if err := w.validate(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// validate performs a depth first search for a cycle.
+func (w *Workflow[O]) validate() error {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var (
+		state = make(map[string]int, len(w.tasks))
+		path  []string
+		visit func(id string) error
+	)
+
+	visit = func(id string) error {
+		switch state[id] {
+		case visited:
+			return nil
+
+		case visiting:
+			for i, step := range path {
+				if step == id {
+					return &CycleError{
+						Path: append(append([]string{}, path[i:]...), id),
+					}
+				}
+			}
+		}
+
+		state[id] = visiting
+		path = append(path, id)
+
+		for _, dependency := range w.tasks[w.index[id]].DependsOn {
+			if err := visit(dependency); err != nil {
+				return err
+			}
+		}
+
+		path = path[:len(path)-1]
+		state[id] = visited
+
+		return nil
+	}
+
+	for _, task := range w.tasks {
+		if err := visit(task.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Run executes the workflow on a worker pool created with the options
+// provided, blocking until every task has either been executed or
+// skipped. When a task fails, the tasks that depend on it, directly or
+// indirectly, are skipped. The returned error joins the TaskError of
+// every task that failed. If the context is cancelled, the tasks not yet
+// started are skipped and the context's error is returned, but only once
+// the tasks already started have finished, since their functions may
+// still be executing.
+func (w *Workflow[O]) Run(ctx context.Context, options ...boost.Option) (Results[O], error) {
+	var wg sync.WaitGroup
+
+	pool, err := boost.NewTaskPool[string, O](ctx, &wg, options...)
+	if err != nil {
+		return nil, err
+	}
+	defer pool.Release(ctx)
+
+	e := &execution[O]{
+		workflow:  w,
+		pool:      pool,
+		results:   make(Results[O], len(w.tasks)),
+		remaining: make(map[string]int, len(w.tasks)),
+		// buffered so that workers never block, even if Run has returned
+		doneCh: make(chan completion[O], len(w.tasks)),
+	}
+
+	for _, task := range w.tasks {
+		e.remaining[task.ID] = len(task.DependsOn)
+	}
+
+	for _, task := range w.tasks {
+		if len(task.DependsOn) == 0 {
+			e.start(ctx, task.ID)
+		}
+	}
+
+	for e.running > 0 {
+		select {
+		case done := <-e.doneCh:
+			e.running--
+			e.complete(ctx, done)
+
+		case <-ctx.Done():
+			return e.cancel(ctx)
+		}
+	}
+
+	return e.results, e.failures()
+}
+
+// cancel waits for the tasks already started to finish, recording their
+// results without starting their dependents, then skips every task that
+// was never started.
+func (e *execution[O]) cancel(ctx context.Context) (Results[O], error) {
+	for ; e.running > 0; e.running-- {
+		done := <-e.doneCh
+		e.results[done.id] = done.result
+	}
+
+	for _, task := range e.workflow.tasks {
+		if _, found := e.results[task.ID]; !found {
+			e.results[task.ID] = Result[O]{
+				Err: &SkippedError{ID: task.ID, Err: ctx.Err()},
+			}
+		}
+	}
+
+	return e.results, ctx.Err()
+}
+
+// start submits the task to the pool, along with the outputs of the tasks
+// it depends on.
+func (e *execution[O]) start(ctx context.Context, id string) {
+	task := e.workflow.tasks[e.workflow.index[id]]
+	inputs := make(map[string]O, len(task.DependsOn))
+
+	for _, dependency := range task.DependsOn {
+		inputs[dependency] = e.results[dependency].Output
+	}
+
+	e.running++
+
+	if err := e.pool.Post(ctx, func() {
+		e.doneCh <- completion[O]{
+			id:     id,
+			result: execute(ctx, task, inputs),
+		}
+	}); err != nil {
+		e.running--
+		e.complete(ctx, completion[O]{
+			id: id,
+			result: Result[O]{
+				Err: err,
+			},
+		})
+	}
+}
+
+// complete records the result of the task, then either starts the
+// dependents that are now ready, or skips all dependents if it failed.
+func (e *execution[O]) complete(ctx context.Context, done completion[O]) {
+	e.results[done.id] = done.result
+
+	if done.result.Err != nil {
+		e.skip(done.id, done.id, done.result.Err)
+
+		return
+	}
+
+	for _, dependent := range e.workflow.dependents[done.id] {
+		if e.remaining[dependent]--; e.remaining[dependent] == 0 {
+			if _, found := e.results[dependent]; !found {
+				e.start(ctx, dependent)
+			}
+		}
+	}
+}
+
+// skip records every task that depends on the task identified, directly
+// or indirectly, as skipped because of the failed task.
+func (e *execution[O]) skip(id, failed string, err error) {
+	for _, dependent := range e.workflow.dependents[id] {
+		if _, found := e.results[dependent]; found {
+			continue
+		}
+
+		e.results[dependent] = Result[O]{
+			Err: &SkippedError{
+				ID:     dependent,
+				Failed: failed,
+				Err:    err,
+			},
+		}
+		e.skip(dependent, failed, err)
+	}
+}
+
+// failures joins the errors of the tasks that failed, in the order in
+// which the tasks were defined.
+func (e *execution[O]) failures() error {
+	var errs []error
+
+	for _, task := range e.workflow.tasks {
+		if err := e.results[task.ID].Err; err != nil && !errors.Is(err, ErrSkipped) {
+			errs = append(errs, &TaskError{ID: task.ID, Err: err})
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// execute invokes the function of the task, converting a panic into an
+// error, so that the workflow is always able to complete.
+func execute[O any](ctx context.Context, task Task[O], inputs map[string]O) (result Result[O]) {
+	defer func() {
+		if pv := recover(); pv != nil {
+			result = Result[O]{
+				Err: &boost.PanicError{
+					ID:    task.ID,
+					Value: pv,
+					Stack: debug.Stack(),
+				},
+			}
+		}
+	}()
+
+	output, err := task.Func(ctx, inputs)
+
+	return Result[O]{
+		Output: output,
+		Err:    err,
+	}
+}
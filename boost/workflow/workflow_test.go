@@ -0,0 +1,191 @@
+package workflow_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // ginkgo ok
+	. "github.com/onsi/gomega"    //nolint:revive // gomega ok
+
+	"github.com/snivilised/lorax/boost"
+	"github.com/snivilised/lorax/boost/workflow"
+)
+
+var errHash = errors.New("hash failed")
+
+// sum returns a task that adds its own value to the outputs of the tasks
+// it depends on.
+func sum(id string, value int, dependsOn ...string) workflow.Task[int] {
+	return workflow.Task[int]{
+		ID:        id,
+		DependsOn: dependsOn,
+		Func: func(_ context.Context, inputs map[string]int) (int, error) {
+			total := value
+			for _, input := range inputs {
+				total += input
+			}
+
+			return total, nil
+		},
+	}
+}
+
+func fail(id string, dependsOn ...string) workflow.Task[int] {
+	return workflow.Task[int]{
+		ID:        id,
+		DependsOn: dependsOn,
+		Func: func(context.Context, map[string]int) (int, error) {
+			return 0, errHash
+		},
+	}
+}
+
+var _ = Describe("Workflow", func() {
+	Context("New", func() {
+		When("tasks form a cycle", func() {
+			It("🧪 should: identify cycle", func() {
+				_, err := workflow.New(
+					sum("a", 1),
+					sum("b", 1, "a", "d"),
+					sum("c", 1, "b"),
+					sum("d", 1, "c"),
+				)
+
+				var cycleErr *workflow.CycleError
+				Expect(errors.As(err, &cycleErr)).To(BeTrue())
+				Expect(err).To(MatchError(workflow.ErrCycle))
+				Expect(cycleErr.Path).To(Equal([]string{"b", "d", "c", "b"}))
+			})
+		})
+
+		DescribeTable("🧪 should: reject invalid workflow",
+			func(tasks []workflow.Task[int], expected error) {
+				_, err := workflow.New(tasks...)
+				Expect(err).To(MatchError(expected))
+			},
+			Entry("duplicate", []workflow.Task[int]{
+				sum("a", 1), sum("a", 2),
+			}, workflow.ErrDuplicateTask),
+			Entry("unknown dependency", []workflow.Task[int]{
+				sum("a", 1, "b"),
+			}, workflow.ErrUnknownDependency),
+			Entry("self dependency", []workflow.Task[int]{
+				sum("a", 1, "a"),
+			}, workflow.ErrCycle),
+			Entry("missing id", []workflow.Task[int]{
+				sum("", 1),
+			}, workflow.ErrInvalidTask),
+		)
+	})
+
+	Context("Run", func() {
+		When("all tasks succeed", func() {
+			It("🧪 should: pass outputs to dependents", func(specCtx SpecContext) {
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				w, err := workflow.New(
+					sum("checksum", 1, "file-1", "file-2"),
+					sum("file-1", 10),
+					sum("file-2", 100),
+					sum("report", 1000, "checksum"),
+				)
+				Expect(err).To(Succeed())
+
+				results, err := w.Run(ctx, boost.WithSize(2))
+				Expect(err).To(Succeed())
+				Expect(results).To(HaveLen(4))
+				Expect(results["checksum"].Output).To(Equal(111))
+				Expect(results["report"].Output).To(Equal(1111))
+			})
+		})
+
+		When("a task fails", func() {
+			It("🧪 should: skip dependents", func(specCtx SpecContext) {
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				w, err := workflow.New(
+					sum("file-1", 10),
+					fail("file-2"),
+					sum("checksum", 1, "file-1", "file-2"),
+					sum("report", 1000, "checksum"),
+					sum("unrelated", 5, "file-1"),
+				)
+				Expect(err).To(Succeed())
+
+				results, err := w.Run(ctx, boost.WithSize(2))
+				Expect(err).To(MatchError(errHash))
+
+				var taskErr *workflow.TaskError
+				Expect(errors.As(err, &taskErr)).To(BeTrue())
+				Expect(taskErr.ID).To(Equal("file-2"))
+
+				for _, id := range []string{"checksum", "report"} {
+					var skippedErr *workflow.SkippedError
+					Expect(errors.As(results[id].Err, &skippedErr)).To(BeTrue())
+					Expect(results[id].Err).To(MatchError(workflow.ErrSkipped))
+					Expect(skippedErr.Failed).To(Equal("file-2"))
+				}
+				Expect(results["unrelated"].Output).To(Equal(15))
+			})
+		})
+
+		When("cancelled", func() {
+			It("🧪 should: skip tasks not yet started", func(specCtx SpecContext) {
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				var once sync.Once
+				w, err := workflow.New(
+					workflow.Task[int]{
+						ID: "blocker",
+						Func: func(ctx context.Context, _ map[string]int) (int, error) {
+							once.Do(cancel)
+							<-ctx.Done()
+
+							return 0, ctx.Err()
+						},
+					},
+					sum("after", 1, "blocker"),
+				)
+				Expect(err).To(Succeed())
+
+				results, err := w.Run(ctx)
+				Expect(err).To(MatchError(context.Canceled))
+				Expect(results["after"].Err).To(MatchError(workflow.ErrSkipped))
+			})
+
+			It("🧪 should: wait for tasks already started", func(specCtx SpecContext) {
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				var finished atomic.Bool
+				w, err := workflow.New(
+					workflow.Task[int]{
+						ID: "slow",
+						Func: func(_ context.Context, _ map[string]int) (int, error) {
+							cancel()
+							time.Sleep(50 * time.Millisecond)
+							finished.Store(true)
+
+							return 42, nil
+						},
+					},
+					sum("after", 1, "slow"),
+				)
+				Expect(err).To(Succeed())
+
+				results, err := w.Run(ctx)
+				Expect(err).To(MatchError(context.Canceled))
+				Expect(finished.Load()).To(BeTrue())
+				Expect(results["slow"].Err).To(Succeed())
+				Expect(results["slow"].Output).To(Equal(42))
+				Expect(results["after"].Err).To(MatchError(workflow.ErrSkipped))
+			})
+		})
+	})
+})
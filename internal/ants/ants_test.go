@@ -162,6 +162,66 @@ var _ = Describe("Ants", func() {
 		})
 	})
 
+	Context("Spawned and Purged", func() {
+		It("🧪 should: count worker lifecycle", func(specCtx SpecContext) {
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			pool, err := ants.NewPool(ctx,
+				ants.WithSize(2),
+				ants.WithExpiryDuration(time.Millisecond*10),
+			)
+			Expect(err).To(Succeed())
+			defer pool.Release(ctx)
+
+			var wg sync.WaitGroup
+
+			block := make(chan struct{})
+			for i := 0; i < 2; i++ {
+				wg.Add(1)
+				Expect(pool.Submit(ctx, func() {
+					defer wg.Done()
+					<-block
+				})).To(Succeed())
+			}
+			Expect(pool.Spawned()).To(BeEquivalentTo(2))
+
+			close(block)
+			wg.Wait()
+			Eventually(pool.Purged).Should(BeEquivalentTo(2))
+			Expect(pool.Spawned()).To(BeEquivalentTo(2))
+		})
+	})
+
+	Context("Pause and Resume", func() {
+		It("🧪 should: withhold workers whilst paused", func(specCtx SpecContext) {
+			ctx, cancel := context.WithCancel(specCtx)
+			defer cancel()
+
+			pool, err := ants.NewPool(ctx, ants.WithSize(2))
+			Expect(err).To(Succeed())
+			defer pool.Release(ctx)
+
+			var wg sync.WaitGroup
+
+			pool.Pause()
+			Expect(pool.IsPaused()).To(BeTrue())
+
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+
+				Expect(pool.Submit(ctx, wg.Done)).To(Succeed())
+			}()
+			Eventually(pool.Waiting).Should(Equal(1))
+			Expect(pool.Running()).To(BeZero())
+
+			pool.Resume()
+			wg.Wait()
+			Expect(pool.IsPaused()).To(BeFalse())
+		})
+	})
+
 	Context("NewPoolWithFunc", func() {
 		Context("Invoke", func() {
 			When("waiting to get worker", func() {
@@ -0,0 +1,296 @@
+// MIT License
+
+// Copyright (c) 2023 Andy Pan
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ants
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// MultiPoolWithFunc consists of multiple pools, from which you will benefit the
+// performance improvement on basis of the fine-grained locking that reduces
+// the lock contention.
+// MultiPoolWithFunc is a good fit for the scenario where you have a large number of
+// tasks to submit, and you don't want the single pool to be the bottleneck.
+type MultiPoolWithFunc struct {
+	pools []*PoolWithFunc
+	index uint32
+	state int32
+	lbs   LoadBalancingStrategy
+}
+
+// NewMultiPoolWithFunc instantiates a MultiPoolWithFunc with a size of the pool list,
+// the pool function and the load-balancing strategy. The size of each pool
+// is defined by the Size option.
+func NewMultiPoolWithFunc(ctx context.Context,
+	size int,
+	pf PoolFunc,
+	lbs LoadBalancingStrategy,
+	options ...Option,
+) (*MultiPoolWithFunc, error) {
+	if size <= 0 {
+		return nil, ErrInvalidPoolIndex
+	}
+
+	if !lbs.valid() {
+		return nil, ErrInvalidLoadBalancingStrategy
+	}
+
+	pools := make([]*PoolWithFunc, size)
+
+	for i := 0; i < size; i++ {
+		pool, err := NewPoolWithFunc(ctx, pf, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		pools[i] = pool
+	}
+
+	return &MultiPoolWithFunc{pools: pools, lbs: lbs}, nil
+}
+
+func (mp *MultiPoolWithFunc) next(lbs LoadBalancingStrategy) (idx int) {
+	switch lbs {
+	case RoundRobin, KeyHash:
+		return int((atomic.AddUint32(&mp.index, 1) - 1) % uint32(len(mp.pools)))
+
+	case LeastTasks:
+		leastTasks := math.MaxInt32
+
+		for i, pool := range mp.pools {
+			if n := pool.Running(); n < leastTasks {
+				leastTasks = n
+				idx = i
+			}
+		}
+
+		return idx
+	}
+
+	return -1
+}
+
+// Invoke submits a task to a pool selected by the load-balancing strategy.
+func (mp *MultiPoolWithFunc) Invoke(ctx context.Context, job InputParam) (err error) {
+	if mp.IsClosed() {
+		return ErrPoolClosed
+	}
+
+	if err = mp.pools[mp.next(mp.lbs)].Invoke(ctx, job); err == nil {
+		return nil
+	}
+
+	if errors.Is(err, ErrPoolOverload) && mp.lbs == RoundRobin {
+		return mp.pools[mp.next(LeastTasks)].Invoke(ctx, job)
+	}
+
+	return err
+}
+
+// InvokeWithKey submits a task to the pool selected by hashing the key when
+// the load-balancing strategy is KeyHash, otherwise the key is ignored and
+// the task is submitted as per Invoke.
+func (mp *MultiPoolWithFunc) InvokeWithKey(ctx context.Context, key string, job InputParam) error {
+	if mp.lbs != KeyHash {
+		return mp.Invoke(ctx, job)
+	}
+
+	if mp.IsClosed() {
+		return ErrPoolClosed
+	}
+
+	return mp.pools[hashIndex(key, len(mp.pools))].Invoke(ctx, job)
+}
+
+// Running returns the number of the currently running workers across all pools.
+func (mp *MultiPoolWithFunc) Running() (n int) {
+	for _, pool := range mp.pools {
+		n += pool.Running()
+	}
+
+	return n
+}
+
+// RunningByIndex returns the number of the currently running workers in the
+// specific pool.
+func (mp *MultiPoolWithFunc) RunningByIndex(idx int) (int, error) {
+	if idx < 0 || idx >= len(mp.pools) {
+		return -1, ErrInvalidPoolIndex
+	}
+
+	return mp.pools[idx].Running(), nil
+}
+
+// Free returns the number of available workers across all pools.
+func (mp *MultiPoolWithFunc) Free() (n int) {
+	for _, pool := range mp.pools {
+		n += pool.Free()
+	}
+
+	return n
+}
+
+// FreeByIndex returns the number of available workers in the specific pool.
+func (mp *MultiPoolWithFunc) FreeByIndex(idx int) (int, error) {
+	if idx < 0 || idx >= len(mp.pools) {
+		return -1, ErrInvalidPoolIndex
+	}
+
+	return mp.pools[idx].Free(), nil
+}
+
+// Waiting returns the number of blocked tasks across all pools.
+func (mp *MultiPoolWithFunc) Waiting() (n int) {
+	for _, pool := range mp.pools {
+		n += pool.Waiting()
+	}
+
+	return n
+}
+
+// WaitingByIndex returns the number of blocked tasks in the specific pool.
+func (mp *MultiPoolWithFunc) WaitingByIndex(idx int) (int, error) {
+	if idx < 0 || idx >= len(mp.pools) {
+		return -1, ErrInvalidPoolIndex
+	}
+
+	return mp.pools[idx].Waiting(), nil
+}
+
+// Spawned returns the number of worker goroutines started across all pools.
+func (mp *MultiPoolWithFunc) Spawned() (n uint64) {
+	for _, pool := range mp.pools {
+		n += pool.Spawned()
+	}
+
+	return n
+}
+
+// Purged returns the number of stale workers cleared across all pools.
+func (mp *MultiPoolWithFunc) Purged() (n uint64) {
+	for _, pool := range mp.pools {
+		n += pool.Purged()
+	}
+
+	return n
+}
+
+// Cap returns the capacity of this multi-pool.
+func (mp *MultiPoolWithFunc) Cap() (n int) {
+	for _, pool := range mp.pools {
+		n += pool.Cap()
+	}
+
+	return n
+}
+
+// Tune resizes each pool in multi-pool.
+//
+// Note that this method doesn't resize the number of pools.
+func (mp *MultiPoolWithFunc) Tune(size int) {
+	for _, pool := range mp.pools {
+		pool.Tune(size)
+	}
+}
+
+// Pause pauses every pool in the multi-pool.
+func (mp *MultiPoolWithFunc) Pause() {
+	for _, pool := range mp.pools {
+		pool.Pause()
+	}
+}
+
+// Resume resumes every pool in the multi-pool.
+func (mp *MultiPoolWithFunc) Resume() {
+	for _, pool := range mp.pools {
+		pool.Resume()
+	}
+}
+
+// IsClosed indicates whether the multi-pool is closed.
+func (mp *MultiPoolWithFunc) IsClosed() bool {
+	return atomic.LoadInt32(&mp.state) == CLOSED
+}
+
+// Release closes the multi-pool and releases the worker queues of
+// every pool.
+func (mp *MultiPoolWithFunc) Release(ctx context.Context) {
+	if !atomic.CompareAndSwapInt32(&mp.state, OPENED, CLOSED) {
+		return
+	}
+
+	for _, pool := range mp.pools {
+		pool.Release(ctx)
+	}
+}
+
+// ReleaseTimeout closes the multi-pool with a timeout, it waits all pools
+// to be closed before timing out. All pools are released before waiting,
+// so the timeout applies to the multi-pool as a whole.
+func (mp *MultiPoolWithFunc) ReleaseTimeout(ctx context.Context, timeout time.Duration) error {
+	if !atomic.CompareAndSwapInt32(&mp.state, OPENED, CLOSED) {
+		return ErrPoolClosed
+	}
+
+	var (
+		deadline = time.Now().Add(timeout)
+		errs     []error
+		released = make([]*workerPool, 0, len(mp.pools))
+	)
+
+	for _, pool := range mp.pools {
+		if err := pool.signal(ctx); err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		released = append(released, &pool.workerPool)
+	}
+
+	if err := awaitRelease(deadline, released...); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Reboot reboots a released multi-pool.
+func (mp *MultiPoolWithFunc) Reboot(ctx context.Context) {
+	if atomic.CompareAndSwapInt32(&mp.state, CLOSED, OPENED) {
+		atomic.StoreUint32(&mp.index, 0)
+
+		for _, pool := range mp.pools {
+			pool.Reboot(ctx)
+		}
+	}
+}
+
+// GetOptions returns the options shared by all pools.
+func (mp *MultiPoolWithFunc) GetOptions() *Options {
+	return mp.pools[0].GetOptions()
+}
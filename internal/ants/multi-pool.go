@@ -0,0 +1,324 @@
+// MIT License
+
+// Copyright (c) 2023 Andy Pan
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package ants
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// LoadBalancingStrategy represents the type of load-balancing algorithm.
+type LoadBalancingStrategy int
+
+const (
+	// RoundRobin distributes task to a list of pools in rotation.
+	RoundRobin LoadBalancingStrategy = 1 << (iota + 1)
+
+	// LeastTasks always selects the pool with the least number of pending tasks.
+	LeastTasks
+
+	// KeyHash selects the pool by hashing a key associated with the task, so
+	// that tasks with the same key are always dispatched to the same pool.
+	// Tasks submitted without a key are distributed in rotation.
+	KeyHash
+)
+
+func (lbs LoadBalancingStrategy) valid() bool {
+	return lbs == RoundRobin || lbs == LeastTasks || lbs == KeyHash
+}
+
+// MultiPool consists of multiple pools, from which you will benefit the
+// performance improvement on basis of the fine-grained locking that reduces
+// the lock contention.
+// MultiPool is a good fit for the scenario where you have a large number of
+// tasks to submit, and you don't want the single pool to be the bottleneck.
+type MultiPool struct {
+	pools []*Pool
+	index uint32
+	state int32
+	lbs   LoadBalancingStrategy
+}
+
+// NewMultiPool instantiates a MultiPool with a size of the pool list and a
+// size per pool, and the load-balancing strategy. The size of each pool
+// is defined by the Size option.
+func NewMultiPool(ctx context.Context,
+	size int,
+	lbs LoadBalancingStrategy,
+	options ...Option,
+) (*MultiPool, error) {
+	if size <= 0 {
+		return nil, ErrInvalidPoolIndex
+	}
+
+	if !lbs.valid() {
+		return nil, ErrInvalidLoadBalancingStrategy
+	}
+
+	pools := make([]*Pool, size)
+
+	for i := 0; i < size; i++ {
+		pool, err := NewPool(ctx, options...)
+		if err != nil {
+			return nil, err
+		}
+
+		pools[i] = pool
+	}
+
+	return &MultiPool{pools: pools, lbs: lbs}, nil
+}
+
+func (mp *MultiPool) next(lbs LoadBalancingStrategy) (idx int) {
+	switch lbs {
+	case RoundRobin, KeyHash:
+		return int((atomic.AddUint32(&mp.index, 1) - 1) % uint32(len(mp.pools)))
+
+	case LeastTasks:
+		leastTasks := math.MaxInt32
+
+		for i, pool := range mp.pools {
+			if n := pool.Running(); n < leastTasks {
+				leastTasks = n
+				idx = i
+			}
+		}
+
+		return idx
+	}
+
+	return -1
+}
+
+// Submit submits a task to a pool selected by the load-balancing strategy.
+func (mp *MultiPool) Submit(ctx context.Context, task TaskFunc) (err error) {
+	if mp.IsClosed() {
+		return ErrPoolClosed
+	}
+
+	if err = mp.pools[mp.next(mp.lbs)].Submit(ctx, task); err == nil {
+		return nil
+	}
+
+	if errors.Is(err, ErrPoolOverload) && mp.lbs == RoundRobin {
+		return mp.pools[mp.next(LeastTasks)].Submit(ctx, task)
+	}
+
+	return err
+}
+
+// SubmitWithKey submits a task to the pool selected by hashing the key when
+// the load-balancing strategy is KeyHash, otherwise the key is ignored and
+// the task is submitted as per Submit.
+func (mp *MultiPool) SubmitWithKey(ctx context.Context, key string, task TaskFunc) error {
+	if mp.lbs != KeyHash {
+		return mp.Submit(ctx, task)
+	}
+
+	if mp.IsClosed() {
+		return ErrPoolClosed
+	}
+
+	return mp.pools[hashIndex(key, len(mp.pools))].Submit(ctx, task)
+}
+
+// Running returns the number of the currently running workers across all pools.
+func (mp *MultiPool) Running() (n int) {
+	for _, pool := range mp.pools {
+		n += pool.Running()
+	}
+
+	return n
+}
+
+// RunningByIndex returns the number of the currently running workers in the
+// specific pool.
+func (mp *MultiPool) RunningByIndex(idx int) (int, error) {
+	if idx < 0 || idx >= len(mp.pools) {
+		return -1, ErrInvalidPoolIndex
+	}
+
+	return mp.pools[idx].Running(), nil
+}
+
+// Free returns the number of available workers across all pools.
+func (mp *MultiPool) Free() (n int) {
+	for _, pool := range mp.pools {
+		n += pool.Free()
+	}
+
+	return n
+}
+
+// FreeByIndex returns the number of available workers in the specific pool.
+func (mp *MultiPool) FreeByIndex(idx int) (int, error) {
+	if idx < 0 || idx >= len(mp.pools) {
+		return -1, ErrInvalidPoolIndex
+	}
+
+	return mp.pools[idx].Free(), nil
+}
+
+// Waiting returns the number of blocked tasks across all pools.
+func (mp *MultiPool) Waiting() (n int) {
+	for _, pool := range mp.pools {
+		n += pool.Waiting()
+	}
+
+	return n
+}
+
+// WaitingByIndex returns the number of blocked tasks in the specific pool.
+func (mp *MultiPool) WaitingByIndex(idx int) (int, error) {
+	if idx < 0 || idx >= len(mp.pools) {
+		return -1, ErrInvalidPoolIndex
+	}
+
+	return mp.pools[idx].Waiting(), nil
+}
+
+// Spawned returns the number of worker goroutines started across all pools.
+func (mp *MultiPool) Spawned() (n uint64) {
+	for _, pool := range mp.pools {
+		n += pool.Spawned()
+	}
+
+	return n
+}
+
+// Purged returns the number of stale workers cleared across all pools.
+func (mp *MultiPool) Purged() (n uint64) {
+	for _, pool := range mp.pools {
+		n += pool.Purged()
+	}
+
+	return n
+}
+
+// Cap returns the capacity of this multi-pool.
+func (mp *MultiPool) Cap() (n int) {
+	for _, pool := range mp.pools {
+		n += pool.Cap()
+	}
+
+	return n
+}
+
+// Tune resizes each pool in multi-pool.
+//
+// Note that this method doesn't resize the number of pools.
+func (mp *MultiPool) Tune(size int) {
+	for _, pool := range mp.pools {
+		pool.Tune(size)
+	}
+}
+
+// Pause pauses every pool in the multi-pool.
+func (mp *MultiPool) Pause() {
+	for _, pool := range mp.pools {
+		pool.Pause()
+	}
+}
+
+// Resume resumes every pool in the multi-pool.
+func (mp *MultiPool) Resume() {
+	for _, pool := range mp.pools {
+		pool.Resume()
+	}
+}
+
+// IsClosed indicates whether the multi-pool is closed.
+func (mp *MultiPool) IsClosed() bool {
+	return atomic.LoadInt32(&mp.state) == CLOSED
+}
+
+// Release closes the multi-pool and releases the worker queues of
+// every pool.
+func (mp *MultiPool) Release(ctx context.Context) {
+	if !atomic.CompareAndSwapInt32(&mp.state, OPENED, CLOSED) {
+		return
+	}
+
+	for _, pool := range mp.pools {
+		pool.Release(ctx)
+	}
+}
+
+// ReleaseTimeout closes the multi-pool with a timeout, it waits all pools
+// to be closed before timing out. All pools are released before waiting,
+// so the timeout applies to the multi-pool as a whole.
+func (mp *MultiPool) ReleaseTimeout(ctx context.Context, timeout time.Duration) error {
+	if !atomic.CompareAndSwapInt32(&mp.state, OPENED, CLOSED) {
+		return ErrPoolClosed
+	}
+
+	var (
+		deadline = time.Now().Add(timeout)
+		errs     []error
+		released = make([]*workerPool, 0, len(mp.pools))
+	)
+
+	for _, pool := range mp.pools {
+		if err := pool.signal(ctx); err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		released = append(released, &pool.workerPool)
+	}
+
+	if err := awaitRelease(deadline, released...); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Reboot reboots a released multi-pool.
+func (mp *MultiPool) Reboot(ctx context.Context) {
+	if atomic.CompareAndSwapInt32(&mp.state, CLOSED, OPENED) {
+		atomic.StoreUint32(&mp.index, 0)
+
+		for _, pool := range mp.pools {
+			pool.Reboot(ctx)
+		}
+	}
+}
+
+// GetOptions returns the options shared by all pools.
+func (mp *MultiPool) GetOptions() *Options {
+	return mp.pools[0].GetOptions()
+}
+
+// hashIndex maps the key onto the index of one of n pools.
+func hashIndex(key string, n int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+
+	return int(h.Sum32() % uint32(n))
+}
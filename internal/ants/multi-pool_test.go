@@ -0,0 +1,137 @@
+package ants_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // ok
+	. "github.com/onsi/gomega"    //nolint:revive // ok
+
+	"github.com/snivilised/lorax/internal/ants"
+)
+
+var _ = Describe("MultiPool", func() {
+	Context("NewMultiPool", func() {
+		When("invalid load-balancing strategy", func() {
+			It("🧪 should: return error", func(specCtx SpecContext) {
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				_, err := ants.NewMultiPool(ctx, 2, ants.LoadBalancingStrategy(-1))
+				Expect(err).To(MatchError(ants.ErrInvalidLoadBalancingStrategy))
+			})
+		})
+
+		When("invalid index", func() {
+			It("🧪 should: return error", func(specCtx SpecContext) {
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				mp, err := ants.NewMultiPool(ctx, 2, ants.RoundRobin,
+					ants.WithSize(5),
+				)
+				Expect(err).To(Succeed())
+				defer mp.Release(ctx)
+
+				_, err = mp.RunningByIndex(2)
+				Expect(err).To(MatchError(ants.ErrInvalidPoolIndex))
+				Expect(mp.Cap()).To(Equal(10))
+			})
+		})
+
+		When("submitting with key", func() {
+			It("🧪 should: dispatch same key to same pool", func(specCtx SpecContext) {
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				mp, err := ants.NewMultiPool(ctx, 4, ants.KeyHash,
+					ants.WithSize(2),
+				)
+				Expect(err).To(Succeed())
+				defer mp.Release(ctx)
+
+				var wg sync.WaitGroup
+
+				block := make(chan struct{})
+				for i := 0; i < 2; i++ {
+					wg.Add(1)
+					Expect(mp.SubmitWithKey(ctx, "same", func() {
+						defer wg.Done()
+						<-block
+					})).To(Succeed())
+				}
+
+				busy := 0
+				for i := 0; i < 4; i++ {
+					if running, _ := mp.RunningByIndex(i); running > 0 {
+						busy++
+					}
+				}
+				Expect(busy).To(Equal(1))
+
+				close(block)
+				wg.Wait()
+			})
+		})
+	})
+
+	Context("ReleaseTimeout", func() {
+		When("workers of every pool overrun timeout", func() {
+			It("🧪 should: time out once for all pools", func(specCtx SpecContext) {
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				const (
+					count   = 4
+					timeout = time.Millisecond * 50
+				)
+
+				mp, err := ants.NewMultiPool(ctx, count, ants.RoundRobin,
+					ants.WithSize(1),
+				)
+				Expect(err).To(Succeed())
+
+				block := make(chan struct{})
+				defer close(block)
+
+				for i := 0; i < count; i++ {
+					Expect(mp.Submit(ctx, func() {
+						<-block
+					})).To(Succeed())
+				}
+				Eventually(mp.Running).Should(Equal(count))
+
+				start := time.Now()
+				Expect(mp.ReleaseTimeout(ctx, timeout)).To(MatchError(ants.ErrTimeout))
+				Expect(time.Since(start)).To(BeNumerically("<", timeout*2))
+			})
+		})
+	})
+
+	Context("NewMultiPoolWithFunc", func() {
+		When("invoked", func() {
+			It("🧪 should: execute all jobs", func(specCtx SpecContext) {
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				var wg sync.WaitGroup
+
+				mp, err := ants.NewMultiPoolWithFunc(ctx, 3, func(ants.InputParam) {
+					wg.Done()
+				}, ants.LeastTasks,
+					ants.WithSize(2),
+				)
+				Expect(err).To(Succeed())
+				defer mp.Release(ctx)
+
+				for i := 0; i < 30; i++ {
+					wg.Add(1)
+					Expect(mp.Invoke(ctx, i)).To(Succeed())
+				}
+				wg.Wait()
+				Expect(mp.Cap()).To(Equal(6))
+			})
+		})
+	})
+})
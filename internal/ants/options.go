@@ -3,6 +3,8 @@ package ants
 import (
 	"runtime"
 	"time"
+
+	"github.com/cenkalti/backoff/v4"
 )
 
 // Option represents the functional option.
@@ -92,6 +94,71 @@ type Options struct {
 
 	// Output options
 	Output *OutputOptions
+
+	// ReorderBound enables ordered output, whereby outputs are released in
+	// order of SequenceNo, rather than in order of completion. It bounds the
+	// number of jobs whose outputs have yet to be released, so is also the
+	// maximum number of outputs buffered whilst awaiting an earlier one. 0
+	// means outputs are released as soon as they are available.
+	ReorderBound uint
+
+	// RecoverPanics indicates whether a panic raised by a job should be
+	// recovered and reported as the error of the job's output, rather than
+	// being passed to the PanicHandler.
+	RecoverPanics bool
+
+	// Priority enables priority based scheduling of jobs. Nil means jobs
+	// are dispatched in the order in which they are posted.
+	Priority *PriorityOptions
+
+	// Batch defines how jobs are grouped into batches by the batch based
+	// pools. Nil means the defaults apply.
+	Batch *BatchOptions
+
+	// Retry defines the policy used to retry failed jobs. Nil means
+	// failed jobs are not retried.
+	Retry *RetryPolicy
+
+	// RateLimit defines the token buckets that throttle the rate at which
+	// jobs are dispatched. Nil means jobs are not rate limited.
+	RateLimit *RateLimit
+
+	// Capacity is the maximum total weight of the jobs in flight, where
+	// each job occupies as many units of capacity as its weight. 0 means
+	// jobs are only bounded by the size of the pool.
+	Capacity int64
+
+	// CircuitBreaker defines the circuit breaker that short-circuits jobs
+	// whilst failures persist. Nil means there is no circuit breaker.
+	CircuitBreaker *CircuitBreaker
+
+	// JobTimeout denotes the maximum amount of time a job is allowed to
+	// run for, measured from when it starts executing. 0 means no timeout.
+	JobTimeout time.Duration
+
+	// KeyFunc derives a key from the input of a job. Since Options is not
+	// generic, this is stored as an interface{} and is expected to be
+	// populated by boost's typed WithKeyFunc option.
+	KeyFunc interface{}
+
+	// Journal records the jobs posted to the pool. Like KeyFunc, this is
+	// expected to be populated by boost's typed WithJournal option.
+	Journal interface{}
+
+	// Deduplicate derives the key by which concurrent jobs are coalesced.
+	// Like KeyFunc, this is expected to be populated by boost's typed
+	// WithDeduplication option.
+	Deduplicate interface{}
+
+	// Cache holds the outputs of completed jobs, along with the key by
+	// which they are cached. Like KeyFunc, this is expected to be
+	// populated by boost's typed WithCache option.
+	Cache interface{}
+
+	// Middleware is the chain of interceptors that wrap the execution of
+	// every job. Like KeyFunc, this is expected to be populated by boost's
+	// typed WithMiddleware option.
+	Middleware interface{}
 }
 
 type InputOptions struct {
@@ -105,6 +172,8 @@ const (
 	// closed when the source of the workload indicates no more jobs will be
 	// submitted, either by closing the input stream or invoking Conclude on the pool.
 	//
+	// Deprecated: the output channel is now closed as soon as the last
+	// outstanding job has completed, so no polling interval is required.
 	MinimumCheckCloseInterval = time.Millisecond * 10
 
 	// MinimumTimeoutOnSend denotes the minimum duration of how long to allow for
@@ -115,6 +184,125 @@ const (
 	MinimumTimeoutOnSend = time.Millisecond * 10
 )
 
+// PriorityOptions defines how jobs are scheduled according to their
+// priority.
+type PriorityOptions struct {
+	// Aging denotes how long a job has to wait for its effective priority
+	// to be raised by 1, which prevents low priority jobs from being
+	// starved. 0 disables aging.
+	Aging time.Duration
+}
+
+// BatchOptions defines how jobs are grouped into batches.
+type BatchOptions struct {
+	// MaxSize denotes the maximum number of jobs in a batch. A batch is
+	// dispatched as soon as it is full.
+	MaxSize uint
+
+	// Linger denotes the maximum amount of time that the first job of a
+	// batch waits for the batch to fill up, before the batch is dispatched
+	// regardless. 0 means a batch is only dispatched once full, or when
+	// the pool is concluded.
+	Linger time.Duration
+}
+
+// RetryPolicy defines how failed jobs are retried.
+type RetryPolicy struct {
+	// MaxAttempts denotes the maximum number of times a job is executed,
+	// including the first attempt.
+	MaxAttempts uint
+
+	// BackOff is a factory function that creates the back off that
+	// determines how long to wait in between successive attempts. A new
+	// back off is created for each job. If not defined, jobs are
+	// retried immediately.
+	BackOff func() backoff.BackOff
+
+	// ShouldRetry determines whether a job that failed with the error
+	// should be retried. If not defined, all errors are retried.
+	ShouldRetry func(err error) bool
+}
+
+// RateLimit defines the token buckets used to throttle the dispatching
+// of jobs. A bucket holds up to its burst of tokens and is refilled at its
+// rate, expressed in tokens per second; each job consumes a single token.
+type RateLimit struct {
+	// Rate and Burst define the bucket shared by all jobs. A Rate of 0
+	// means there is no shared limit.
+	Rate  float64
+	Burst int
+
+	// KeyRate and KeyBurst define a separate bucket for each distinct key,
+	// as derived from the input by the key function. This is in addition
+	// to the shared bucket. A KeyRate of 0 means there is no per-key limit.
+	KeyRate  float64
+	KeyBurst int
+
+	// NonBlocking indicates that a job for which no token is available
+	// should be rejected, rather than waiting for a token.
+	NonBlocking bool
+}
+
+// CircuitState denotes the state of a circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed is the normal state, in which all jobs are executed.
+	CircuitClosed CircuitState = iota
+
+	// CircuitOpen is the state in which all jobs are short-circuited.
+	CircuitOpen
+
+	// CircuitHalfOpen is the state, entered once the cool down period has
+	// elapsed, in which a limited number of trial jobs are executed to
+	// determine whether the circuit can be closed again.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	}
+
+	return "unknown"
+}
+
+// CircuitBreaker defines when the circuit trips and how it recovers.
+type CircuitBreaker struct {
+	// ConsecutiveFailures denotes the number of successive failures that
+	// trips the circuit. 0 means the circuit does not trip on consecutive
+	// failures.
+	ConsecutiveFailures uint
+
+	// FailureRate denotes the proportion of failed jobs, in the range
+	// (0, 1], that trips the circuit, once at least MinRequests jobs have
+	// completed. 0 means the circuit does not trip on failure rate.
+	FailureRate float64
+	MinRequests uint
+
+	// Interval is the period after which the counts used to evaluate the
+	// thresholds are cleared whilst the circuit is closed. 0 means the
+	// counts are only cleared when the circuit changes state.
+	Interval time.Duration
+
+	// CoolDown is how long the circuit remains open before becoming
+	// half-open.
+	CoolDown time.Duration
+
+	// HalfOpenProbes is the number of trial jobs that must succeed in the
+	// half-open state for the circuit to close; any failure re-opens the
+	// circuit. Defaults to 1.
+	HalfOpenProbes uint
+
+	// OnStateChange is invoked whenever the circuit changes state.
+	OnStateChange func(from, to CircuitState)
+}
+
 type OutputOptions struct {
 	// BufferSize
 	BufferSize uint
@@ -124,6 +312,8 @@ type OutputOptions struct {
 	// of the workload indicates no more jobs will be submitted, either
 	// by closing the input stream or invoking Conclude on the pool.
 	//
+	// Deprecated: the output channel is now closed as soon as the last
+	// outstanding job has completed, so this interval is no longer used.
 	CheckCloseInterval time.Duration
 
 	// TimeoutOnSend denotes how long to allow for when sending output.
@@ -214,6 +404,86 @@ func WithInput(size uint) Option {
 	}
 }
 
+// WithRecoverPanics indicates whether panics raised by jobs should be
+// recovered and reported in the job's output.
+func WithRecoverPanics(enabled bool) Option {
+	return func(opts *Options) {
+		opts.RecoverPanics = enabled
+	}
+}
+
+// WithPriority enables priority based scheduling of jobs, with the
+// specified aging period.
+func WithPriority(aging time.Duration) Option {
+	return func(opts *Options) {
+		opts.Priority = &PriorityOptions{
+			Aging: aging,
+		}
+	}
+}
+
+// WithBatch sets up the maximum size of a batch and how long the first
+// job of a batch waits for it to fill up.
+func WithBatch(maxSize uint, linger time.Duration) Option {
+	return func(opts *Options) {
+		opts.Batch = &BatchOptions{
+			MaxSize: maxSize,
+			Linger:  linger,
+		}
+	}
+}
+
+// WithRetry sets up the policy used to retry failed jobs.
+func WithRetry(policy RetryPolicy) Option { //nolint:gocritic // heavy options not important
+	return func(opts *Options) {
+		opts.Retry = &policy
+	}
+}
+
+// WithRateLimit sets up the token buckets that throttle the dispatching
+// of jobs.
+func WithRateLimit(limit RateLimit) Option {
+	return func(opts *Options) {
+		opts.RateLimit = &limit
+	}
+}
+
+// WithCapacity sets up the maximum total weight of the jobs in flight.
+func WithCapacity(capacity int64) Option {
+	return func(opts *Options) {
+		opts.Capacity = capacity
+	}
+}
+
+// WithCircuitBreaker sets up the circuit breaker that short-circuits
+// jobs whilst failures persist.
+func WithCircuitBreaker(breaker CircuitBreaker) Option { //nolint:gocritic // heavy options not important
+	return func(opts *Options) {
+		opts.CircuitBreaker = &breaker
+	}
+}
+
+// WithJobTimeout sets up the maximum amount of time each job is allowed
+// to run for.
+func WithJobTimeout(timeout time.Duration) Option {
+	return func(opts *Options) {
+		opts.JobTimeout = timeout
+	}
+}
+
+// WithOrderedOutput requests that outputs are released in order of
+// SequenceNo, with at most bound jobs awaiting the release of their
+// outputs at any one time. Only applies when WithOutput is also specified.
+func WithOrderedOutput(bound uint) Option {
+	return func(opts *Options) {
+		opts.ReorderBound = bound
+	}
+}
+
+// WithOutput requests that the outputs of jobs are sent to an output
+// stream of the specified buffer size. The interval is deprecated and no
+// longer used; it is retained only for compatibility. Pass 0 for interval
+// in new code.
 func WithOutput(size uint, interval, timeout time.Duration) Option {
 	return func(opts *Options) {
 		opts.Output = &OutputOptions{
@@ -62,6 +62,7 @@ func (p *PoolWithFunc) purgeStaleWorkers(purgeCtx context.Context) {
 		var isDormant bool
 		p.lock.Lock()
 		staleWorkers := p.workers.refresh(p.o.ExpiryDuration)
+		p.addPurged(len(staleWorkers))
 		n := p.Running()
 		isDormant = n == 0 || n == len(staleWorkers)
 		p.lock.Unlock()
@@ -197,7 +198,7 @@ func (p *PoolWithFunc) Invoke(ctx context.Context, job InputParam) error {
 
 	w, err := p.retrieveWorker()
 	if w != nil {
-		w.sendParam(ctx, job)
+		return w.sendParam(ctx, job)
 	}
 
 	return err
@@ -218,21 +219,26 @@ func (p *PoolWithFunc) retrieveWorker() (w worker, err error) {
 	p.lock.Lock()
 
 retry:
-	// First try to fetch the worker from the queue.
-	if w = p.workers.detach(); w != nil {
-		p.lock.Unlock()
-
-		return //nolint:nakedret // wtf
-	}
+	// Whilst the pool is paused, no worker is handed out, so the caller
+	// is treated as if the pool were at capacity.
+	if !p.IsPaused() {
+		// First try to fetch the worker from the queue.
+		if w = p.workers.detach(); w != nil {
+			p.lock.Unlock()
+
+			return //nolint:nakedret // wtf
+		}
 
-	// If the worker queue is empty, and we don't run out of the pool capacity,
-	// then just spawn a new worker goroutine.
-	if capacity := p.Cap(); capacity == -1 || capacity > p.Running() {
-		p.lock.Unlock()
-		w, _ = p.workerCache.Get().(*goWorkerWithFunc)
-		w.run()
+		// If the worker queue is empty, and we don't run out of the pool capacity,
+		// then just spawn a new worker goroutine.
+		if capacity := p.Cap(); capacity == -1 || capacity > p.Running() {
+			p.lock.Unlock()
+			w, _ = p.workerCache.Get().(*goWorkerWithFunc)
+			w.run()
+			p.addSpawned()
 
-		return //nolint:nakedret // wtf
+			return //nolint:nakedret // wtf
+		}
 	}
 
 	// Bail out early if it's in nonblocking mode or the number of pending callers
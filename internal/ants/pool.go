@@ -62,6 +62,7 @@ func (p *Pool) purgeStaleWorkers(purgeCtx context.Context) {
 		var isDormant bool
 		p.lock.Lock()
 		staleWorkers := p.workers.refresh(p.o.ExpiryDuration)
+		p.addPurged(len(staleWorkers))
 		n := p.Running()
 		isDormant = n == 0 || n == len(staleWorkers)
 		p.lock.Unlock()
@@ -193,7 +194,7 @@ func (p *Pool) Submit(ctx context.Context, task TaskFunc) error {
 
 	w, err := p.retrieveWorker()
 	if w != nil {
-		w.sendTask(ctx, task)
+		return w.sendTask(ctx, task)
 	}
 
 	return err
@@ -214,21 +215,26 @@ func (p *Pool) retrieveWorker() (w worker, err error) {
 	p.lock.Lock() // why isn't the unlock just deferred?
 
 retry:
-	// First try to fetch the worker from the queue.
-	if w = p.workers.detach(); w != nil {
-		p.lock.Unlock()
-
-		return //nolint:nakedret // wtf
-	}
+	// Whilst the pool is paused, no worker is handed out, so the caller
+	// is treated as if the pool were at capacity.
+	if !p.IsPaused() {
+		// First try to fetch the worker from the queue.
+		if w = p.workers.detach(); w != nil {
+			p.lock.Unlock()
+
+			return //nolint:nakedret // wtf
+		}
 
-	// If the worker queue is empty, and we don't run out of the pool capacity,
-	// then just spawn a new worker goroutine.
-	if capacity := p.Cap(); capacity == -1 || capacity > p.Running() {
-		p.lock.Unlock()
-		w, _ = p.workerCache.Get().(*goWorker)
-		w.run()
+		// If the worker queue is empty, and we don't run out of the pool capacity,
+		// then just spawn a new worker goroutine.
+		if capacity := p.Cap(); capacity == -1 || capacity > p.Running() {
+			p.lock.Unlock()
+			w, _ = p.workerCache.Get().(*goWorker)
+			w.run()
+			p.addSpawned()
 
-		return //nolint:nakedret // wtf
+			return //nolint:nakedret // wtf
+		}
 	}
 
 	// Bail out early if it's in nonblocking mode or the number of pending
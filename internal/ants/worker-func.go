@@ -88,13 +88,15 @@ func (w *goWorkerWithFunc) lastUsedTime() time.Time {
 	return w.lastUsed
 }
 
-func (w *goWorkerWithFunc) sendTask(context.Context, TaskFunc) {
+func (w *goWorkerWithFunc) sendTask(context.Context, TaskFunc) error {
 	panic("unreachable")
 }
 
-func (w *goWorkerWithFunc) sendParam(ctx context.Context, job InputParam) {
+func (w *goWorkerWithFunc) sendParam(ctx context.Context, job InputParam) error {
 	select {
 	case <-ctx.Done():
+		return ctx.Err()
 	case w.inputCh <- job:
+		return nil
 	}
 }
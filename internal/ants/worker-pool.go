@@ -32,6 +32,16 @@ type workerPool struct {
 	// waiting is the number of the goroutines already been blocked on pool.Invoke(), protected by pool.lock
 	waiting int32
 
+	// spawned is the number of worker goroutines started over the
+	// lifetime of the pool.
+	spawned uint64
+
+	// purged is the number of stale workers cleared by the purge loop.
+	purged uint64
+
+	// paused indicates that workers are not to be handed new tasks.
+	paused int32
+
 	purgeDone int32
 	stopPurge context.CancelFunc
 
@@ -64,6 +74,16 @@ func (p *workerPool) Waiting() int {
 	return int(atomic.LoadInt32(&p.waiting))
 }
 
+// Spawned returns the number of worker goroutines started so far.
+func (p *workerPool) Spawned() uint64 {
+	return atomic.LoadUint64(&p.spawned)
+}
+
+// Purged returns the number of stale workers cleared so far.
+func (p *workerPool) Purged() uint64 {
+	return atomic.LoadUint64(&p.purged)
+}
+
 // Cap returns the capacity of this pool.
 func (p *workerPool) Cap() int {
 	return int(atomic.LoadInt32(&p.capacity))
@@ -87,6 +107,27 @@ func (p *workerPool) Tune(size int) {
 	}
 }
 
+// Pause prevents the pool from handing tasks to workers. Tasks already
+// running are allowed to complete, but submitters are either blocked or
+// rejected as per the blocking options, as if the pool were at capacity.
+func (p *workerPool) Pause() {
+	atomic.StoreInt32(&p.paused, 1)
+}
+
+// Resume allows a paused pool to continue handing tasks to workers.
+func (p *workerPool) Resume() {
+	if atomic.CompareAndSwapInt32(&p.paused, 1, 0) {
+		p.lock.Lock()
+		p.cond.Broadcast()
+		p.lock.Unlock()
+	}
+}
+
+// IsPaused indicates whether the pool is paused.
+func (p *workerPool) IsPaused() bool {
+	return atomic.LoadInt32(&p.paused) == 1
+}
+
 // IsClosed indicates whether the pool is closed.
 func (p *workerPool) IsClosed() bool {
 	return atomic.LoadInt32(&p.state) == CLOSED
@@ -116,17 +157,49 @@ func (p *workerPool) Release(ctx context.Context) {
 // ReleaseTimeout is like Release but with a timeout, it waits all workers
 // to exit before timing out.
 func (p *workerPool) ReleaseTimeout(ctx context.Context, timeout time.Duration) error {
+	if err := p.signal(ctx); err != nil {
+		return err
+	}
+
+	return awaitRelease(time.Now().Add(timeout), p)
+}
+
+// signal releases the pool, without waiting for its workers to exit.
+func (p *workerPool) signal(ctx context.Context) error {
 	purge := (!p.o.DisablePurge && p.stopPurge == nil)
 	if p.IsClosed() || purge || p.stopTicktock == nil {
 		return ErrPoolClosed
 	}
 	p.Release(ctx)
 
-	endTime := time.Now().Add(timeout)
-	for time.Now().Before(endTime) {
-		if p.Running() == 0 &&
-			(p.o.DisablePurge || atomic.LoadInt32(&p.purgeDone) == 1) &&
-			atomic.LoadInt32(&p.ticktockDone) == 1 {
+	return nil
+}
+
+// released indicates whether all workers, along with the purge and
+// ticktock go routines, of a released pool have exited.
+func (p *workerPool) released() bool {
+	return p.Running() == 0 &&
+		(p.o.DisablePurge || atomic.LoadInt32(&p.purgeDone) == 1) &&
+		atomic.LoadInt32(&p.ticktockDone) == 1
+}
+
+// awaitRelease waits until all the pools, which must have been signalled,
+// have been released, or the deadline is reached. Waiting for multiple
+// pools against a single deadline ensures that a multi-pool is released
+// within the timeout, rather than within a timeout for each of its pools.
+func awaitRelease(deadline time.Time, pools ...*workerPool) error {
+	for time.Now().Before(deadline) {
+		done := true
+
+		for _, p := range pools {
+			if !p.released() {
+				done = false
+
+				break
+			}
+		}
+
+		if done {
 			return nil
 		}
 		time.Sleep(releaseTimeoutInterval * time.Millisecond)
@@ -143,6 +216,14 @@ func (p *workerPool) addWaiting(delta int) {
 	atomic.AddInt32(&p.waiting, int32(delta))
 }
 
+func (p *workerPool) addSpawned() {
+	atomic.AddUint64(&p.spawned, 1)
+}
+
+func (p *workerPool) addPurged(n int) {
+	atomic.AddUint64(&p.purged, uint64(n))
+}
+
 func (p *workerPool) GetOptions() *Options {
 	return p.o
 }
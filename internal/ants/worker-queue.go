@@ -40,8 +40,8 @@ type worker interface {
 	run()
 	finish(context.Context)
 	lastUsedTime() time.Time
-	sendTask(context.Context, TaskFunc)
-	sendParam(context.Context, InputParam)
+	sendTask(context.Context, TaskFunc) error
+	sendParam(context.Context, InputParam) error
 }
 
 type workerQueue interface {
@@ -85,13 +85,15 @@ func (w *goWorker) lastUsedTime() time.Time {
 	return w.lastUsed
 }
 
-func (w *goWorker) sendTask(ctx context.Context, fn TaskFunc) {
+func (w *goWorker) sendTask(ctx context.Context, fn TaskFunc) error {
 	select {
 	case <-ctx.Done():
+		return ctx.Err()
 	case w.taskCh <- fn:
+		return nil
 	}
 }
 
-func (w *goWorker) sendParam(context.Context, InputParam) {
+func (w *goWorker) sendParam(context.Context, InputParam) error {
 	panic("unreachable")
 }
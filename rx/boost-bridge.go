@@ -0,0 +1,245 @@
+package rx
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/snivilised/lorax/boost"
+	"github.com/snivilised/lorax/enums"
+)
+
+// Poster represents a boost worker pool that executes tasks, such as
+// boost.TaskPool or boost.MultiTaskPool, on which an operator can
+// execute its function. See WithBoostPool.
+type Poster interface {
+	Post(ctx context.Context, task boost.TaskFunc) error
+}
+
+// FromJobOutputs creates an Observable from the output stream of a boost
+// worker pool, such as a ManifoldFuncPool. The payload of each output is
+// emitted as a value, whereas the output of a job that failed is emitted
+// as an error item, whose error is a boost.JobError identifying the job.
+// The Observable completes when the output stream is closed.
+func FromJobOutputs[O any](outputs boost.JobOutputStreamR[O], opts ...Option[O]) Observable[O] {
+	option := parseOptions(opts...)
+	ctx := option.buildContext(emptyContext)
+	next := option.buildChannel()
+
+	go func() {
+		defer close(next)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case output, ok := <-outputs:
+				if !ok {
+					return
+				}
+
+				item := Of(output.Payload)
+
+				if output.Error != nil {
+					item = Error[O](&boost.JobError{
+						ID:         output.ID,
+						SequenceNo: output.SequenceNo,
+						Err:        output.Error,
+					})
+				}
+
+				if !item.SendContext(ctx, next) {
+					return
+				}
+			}
+		}
+	}()
+
+	return FromChannel(next, opts...)
+}
+
+// Feed sends the values emitted by the Observable into the source stream
+// of a boost worker pool, which is closed, thereby concluding the pool,
+// once the Observable completes or the context is cancelled. Error items
+// are not sent to the pool; they are passed to onError instead, if
+// defined. The returned channel is closed once feeding has finished.
+func Feed[I any](ctx context.Context,
+	observable Observable[I],
+	source boost.SourceStreamW[I],
+	onError ErrFunc,
+) Disposed {
+	disposed := make(chan struct{})
+
+	go func() {
+		defer close(disposed)
+		defer close(source)
+
+		observe := observable.Observe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+
+				if item.IsError() {
+					if onError != nil {
+						onError(item.E)
+					}
+
+					continue
+				}
+
+				select {
+				case source <- item.V:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return disposed
+}
+
+// boostMapOperator is the operator of Map, when executing on a boost
+// pool. Each item is applied by a task posted to the pool, so the items
+// are emitted in the order in which they complete.
+type boostMapOperator[T any] struct {
+	apply  Func[T]
+	poster Poster
+	wg     sync.WaitGroup
+	failed atomic.Bool
+}
+
+func (op *boostMapOperator[T]) next(ctx context.Context,
+	item Item[T], dst chan<- Item[T], operatorOptions operatorOptions[T],
+) {
+	// a task that failed can only stop the operator once control returns
+	// to the sequential loop, so that subsequent items are not posted.
+	if op.failed.Load() {
+		operatorOptions.stop()
+
+		return
+	}
+
+	op.wg.Add(1)
+
+	if err := op.poster.Post(ctx, func() {
+		defer op.wg.Done()
+
+		res, err := op.apply(ctx, item.V)
+
+		if err != nil {
+			op.failed.Store(true)
+			Error[T](err).SendContext(ctx, dst)
+
+			return
+		}
+
+		Of(res).SendContext(ctx, dst)
+	}); err != nil {
+		op.wg.Done()
+		Error[T](err).SendContext(ctx, dst)
+		operatorOptions.stop()
+	}
+}
+
+func (op *boostMapOperator[T]) err(ctx context.Context,
+	item Item[T], dst chan<- Item[T], operatorOptions operatorOptions[T],
+) {
+	defaultErrorFuncOperator(ctx, item, dst, operatorOptions)
+}
+
+// end waits for the outstanding tasks, so that they have emitted their
+// items before the destination channel is closed.
+func (op *boostMapOperator[T]) end(_ context.Context, _ chan<- Item[T]) {
+	op.wg.Wait()
+}
+
+func (op *boostMapOperator[T]) gatherNext(ctx context.Context,
+	item Item[T], dst chan<- Item[T], _ operatorOptions[T],
+) {
+	item.SendContext(ctx, dst)
+}
+
+// flatMapOnPool is the implementation of FlatMap, when executing on a
+// boost pool. Each item is applied, and the resulting Observable
+// observed, by a task posted to the pool, so the inner Observables are
+// merged in the order in which their items are emitted.
+func (o *ObservableImpl[T]) flatMapOnPool(apply ItemToObservable[T],
+	poster Poster, opts ...Option[T],
+) Observable[T] {
+	f := func(ctx context.Context, next chan Item[T], option Option[T], opts ...Option[T]) {
+		var wg sync.WaitGroup
+
+		ctx, cancel := context.WithCancel(ctx)
+
+		defer close(next)
+		defer cancel()
+		defer wg.Wait()
+
+		stop := option.getErrorStrategy() == enums.StopOnError
+		observe := o.Observe(opts...)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case item, ok := <-observe:
+				if !ok {
+					return
+				}
+
+				wg.Add(1)
+
+				if err := poster.Post(ctx, func() {
+					defer wg.Done()
+
+					observe2 := apply(item).Observe(opts...)
+
+					for {
+						select {
+						case <-ctx.Done():
+							return
+
+						case item, ok := <-observe2:
+							if !ok {
+								return
+							}
+
+							if item.IsError() {
+								item.SendContext(ctx, next)
+
+								if stop {
+									cancel()
+
+									return
+								}
+							} else if !item.SendContext(ctx, next) {
+								return
+							}
+						}
+					}
+				}); err != nil {
+					wg.Done()
+					Error[T](err).SendContext(ctx, next)
+
+					if stop {
+						cancel()
+
+						return
+					}
+				}
+			}
+		}
+	}
+
+	return customObservableOperator(o.parent, f, opts...)
+}
@@ -0,0 +1,178 @@
+package rx_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // ginkgo ok
+	. "github.com/onsi/gomega"    //nolint:revive // gomega ok
+
+	"github.com/snivilised/lorax/boost"
+	"github.com/snivilised/lorax/rx"
+)
+
+var errBridge = errors.New("bridge failed")
+
+var _ = Describe("Boost bridge", func() {
+	triple := func(input int) (int, error) {
+		if input == 3 {
+			return 0, errBridge
+		}
+
+		return input * 3, nil
+	}
+
+	Context("FromJobOutputs", func() {
+		When("pool emits outputs", func() {
+			It("🧪 should: emit payloads and job errors", func(specCtx SpecContext) {
+				var wg sync.WaitGroup
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewManifoldFuncPool(ctx, triple, &wg,
+					boost.WithOutput(10, 0, time.Second),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				for i := 1; i <= 4; i++ {
+					Expect(pool.Post(ctx, i)).To(Succeed())
+				}
+				pool.Conclude(ctx)
+
+				var (
+					values []int
+					errs   []error
+				)
+
+				for item := range rx.FromJobOutputs(pool.Observe()).Observe() {
+					if item.IsError() {
+						errs = append(errs, item.E)
+
+						continue
+					}
+					values = append(values, item.V)
+				}
+
+				Expect(values).To(ConsistOf(3, 6, 12))
+				Expect(errs).To(HaveLen(1))
+
+				var jobErr *boost.JobError
+				Expect(errors.As(errs[0], &jobErr)).To(BeTrue())
+				Expect(jobErr.SequenceNo).To(Equal(3))
+				Expect(jobErr).To(MatchError(errBridge))
+				wg.Wait()
+			})
+		})
+	})
+
+	Context("Feed", func() {
+		When("observable completes", func() {
+			It("🧪 should: post values and conclude pool", func(specCtx SpecContext) {
+				var (
+					wg   sync.WaitGroup
+					errs []error
+				)
+
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				pool, err := boost.NewManifoldFuncPool(ctx, triple, &wg,
+					boost.WithOutput(10, 0, time.Second),
+				)
+				Expect(err).To(Succeed())
+				defer pool.Release(ctx)
+
+				disposed := rx.Feed(ctx,
+					testObservable[int](ctx, 1, 2, errBridge, 4),
+					pool.Source(ctx, &wg),
+					func(err error) {
+						errs = append(errs, err)
+					},
+				)
+
+				var payloads []int
+				for output := range pool.Observe() {
+					Expect(output.Error).To(Succeed())
+					payloads = append(payloads, output.Payload)
+				}
+
+				Eventually(disposed).Should(BeClosed())
+				Expect(payloads).To(ConsistOf(3, 6, 12))
+				Expect(errs).To(ConsistOf(MatchError(errBridge)))
+				wg.Wait()
+			})
+		})
+	})
+
+	Context("WithBoostPool", func() {
+		var (
+			wg   sync.WaitGroup
+			pool *boost.TaskPool[int, int]
+		)
+
+		BeforeEach(func(specCtx SpecContext) {
+			var err error
+
+			pool, err = boost.NewTaskPool[int, int](specCtx, &wg, boost.WithSize(2))
+			Expect(err).To(Succeed())
+		})
+
+		AfterEach(func(specCtx SpecContext) {
+			pool.Release(specCtx)
+		})
+
+		When("Map", func() {
+			It("🧪 should: execute function on pool", func(specCtx SpecContext) {
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				obs := testObservable[int](ctx, 1, 2, 3, 4).Map(func(_ context.Context, v int) (int, error) {
+					return v * 10, nil
+				}, rx.WithBoostPool[int](pool))
+
+				rx.Assert(ctx, obs, rx.ContainItems[int]{
+					Expected: []int{10, 20, 30, 40},
+				}, rx.HasNoError[int]{})
+				Eventually(func() uint64 {
+					return pool.Stats().Completed
+				}).Should(BeEquivalentTo(4))
+			})
+
+			It("🧪 should: emit error", func(specCtx SpecContext) {
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				obs := testObservable[int](ctx, 1, 2, 3).Map(func(_ context.Context, v int) (int, error) {
+					if v == 2 {
+						return 0, errBridge
+					}
+
+					return v, nil
+				}, rx.WithBoostPool[int](pool))
+
+				rx.Assert(ctx, obs, rx.HasError[int]{
+					Expected: []error{errBridge},
+				})
+			})
+		})
+
+		When("FlatMap", func() {
+			It("🧪 should: observe inner observables on pool", func(specCtx SpecContext) {
+				ctx, cancel := context.WithCancel(specCtx)
+				defer cancel()
+
+				obs := testObservable[int](ctx, 1, 2, 3).FlatMap(func(item rx.Item[int]) rx.Observable[int] {
+					return testObservable[int](ctx, item.V*10, item.V*100)
+				}, rx.WithBoostPool[int](pool))
+
+				rx.Assert(ctx, obs, rx.ContainItems[int]{
+					Expected: []int{10, 100, 20, 200, 30, 300},
+				}, rx.HasNoError[int]{})
+			})
+		})
+	})
+})
@@ -840,10 +840,15 @@ func (op *firstOrDefaultOperator[T]) gatherNext(_ context.Context, _ Item[T],
 }
 
 // FlatMap transforms the items emitted by an Observable into Observables,
-// then flatten the emissions from those into a single Observable.
+// then flatten the emissions from those into a single Observable. With
+// WithBoostPool, each item is applied and observed on the boost pool.
 func (o *ObservableImpl[T]) FlatMap(apply ItemToObservable[T],
 	opts ...Option[T],
 ) Observable[T] {
+	if poster := parseOptions(opts...).getPoster(); poster != nil {
+		return o.flatMapOnPool(apply, poster, opts...)
+	}
+
 	f := func(ctx context.Context, next chan Item[T], option Option[T], opts ...Option[T]) {
 		defer close(next)
 
@@ -1316,12 +1321,24 @@ func (op *minOperator[T]) gatherNext(ctx context.Context,
 }
 
 // Map transforms the items emitted by an Observable by applying a function to each item.
+// With WithBoostPool, the function is executed on the boost pool.
 func (o *ObservableImpl[T]) Map(apply Func[T], opts ...Option[T]) Observable[T] {
 	const (
 		forceSeq     = false
 		bypassGather = true
 	)
 
+	// the concurrency of a boost pool is bounded by the pool itself, so
+	// the operator is driven sequentially, posting each item to the pool.
+	if poster := parseOptions(opts...).getPoster(); poster != nil {
+		return observable(o.parent, o, func() operator[T] {
+			return &boostMapOperator[T]{
+				apply:  apply,
+				poster: poster,
+			}
+		}, true, bypassGather, opts...)
+	}
+
 	return observable(o.parent, o, func() operator[T] {
 		return &mapOperator[T]{
 			apply: apply,
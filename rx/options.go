@@ -37,6 +37,7 @@ type Option[T any] interface {
 	toPropagate() bool
 	isEagerObservation() bool
 	getPool() (bool, int)
+	getPoster() Poster
 	buildChannel() chan Item[T]
 	buildContext(parent context.Context) context.Context
 	getBackPressureStrategy() enums.BackPressureStrategy
@@ -54,6 +55,7 @@ type funcOption[T any] struct {
 	ctx                  context.Context
 	observation          enums.ObservationStrategy
 	pool                 int
+	poster               Poster
 	backPressureStrategy enums.BackPressureStrategy
 	onErrorStrategy      enums.OnErrorStrategy
 	propagate            bool
@@ -75,6 +77,10 @@ func (fdo *funcOption[T]) getPool() (b bool, p int) {
 	return fdo.pool > 0, fdo.pool
 }
 
+func (fdo *funcOption[T]) getPoster() Poster {
+	return fdo.poster
+}
+
 func (fdo *funcOption[T]) buildChannel() chan Item[T] {
 	if fdo.isBuffer {
 		return make(chan Item[T], fdo.buffer)
@@ -177,6 +183,15 @@ func WithPool[T any](pool int) Option[T] {
 	})
 }
 
+// WithBoostPool allows to specify a boost task pool on which to execute
+// the function of an operator, so that its concurrency is bounded by the
+// pool rather than by WithPool. Only applies to Map and FlatMap.
+func WithBoostPool[T any](poster Poster) Option[T] {
+	return newFuncOption(func(options *funcOption[T]) {
+		options.poster = poster
+	})
+}
+
 // WithCPUPool allows to specify an execution pool based on the number of logical CPUs.
 func WithCPUPool[T any]() Option[T] {
 	return newFuncOption(func(options *funcOption[T]) {